@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// error codes raised by the HTTP layer itself, use case errors carry their own
+const (
+	codeInvalidRequest    = "invalid_request"
+	codeValidationFailed  = "validation_failed"
+	codeForbidden         = "forbidden"
+	codeInsufficientScope = "insufficient_scope"
+	codeSessionRequired   = "session_required"
+	codeRouteNotFound     = "route_not_found"
+	codeBadPrecondition   = "bad_precondition"
+	codeInternal          = "internal_error"
+)
+
+// apiError is an error raised before a use case runs: malformed input or a
+// request rejected by a middleware
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+	Details []model.FieldError
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+var (
+	errTokenMissing    = &apiError{Status: http.StatusUnauthorized, Code: codeTokenMissing, Message: "missing token"}
+	errTokenExpired    = &apiError{Status: http.StatusUnauthorized, Code: codeTokenExpired, Message: "token expired"}
+	errTokenInvalid    = &apiError{Status: http.StatusUnauthorized, Code: codeTokenInvalid, Message: "invalid token"}
+	errForbidden       = &apiError{Status: http.StatusForbidden, Code: codeForbidden, Message: "forbidden"}
+	errSessionRequired = &apiError{Status: http.StatusForbidden, Code: codeSessionRequired, Message: "not allowed with a personal access token"}
+	errRouteNotFound   = &apiError{Status: http.StatusNotFound, Code: codeRouteNotFound, Message: "route not found"}
+	errBadPrecondition = &apiError{Status: http.StatusPreconditionFailed, Code: codeBadPrecondition, Message: "If-Match must be a single strong ETag or *"}
+)
+
+var errorStatuses = map[usecase.Kind]int{
+	usecase.KindInvalid:            http.StatusUnprocessableEntity,
+	usecase.KindUnauthorized:       http.StatusUnauthorized,
+	usecase.KindForbidden:          http.StatusForbidden,
+	usecase.KindNotFound:           http.StatusNotFound,
+	usecase.KindConflict:           http.StatusConflict,
+	usecase.KindPreconditionFailed: http.StatusPreconditionFailed,
+	usecase.KindTooManyRequests:    http.StatusTooManyRequests,
+}
+
+// ErrorHandler writes the response for the last error a handler or middleware
+// added with c.Error, so they don't have to format errors themselves
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+
+		status, body := errorResponse(err)
+		body.RequestID = c.GetString(requestIDKey)
+		if status == http.StatusInternalServerError {
+			log.Printf("request %s %s %s: %v", body.RequestID, c.Request.Method, c.FullPath(), err)
+		}
+
+		var locked *usecase.LoginLockedError
+		if errors.As(err, &locked) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		}
+
+		c.JSON(status, model.ErrorResponse{Error: body})
+	}
+}
+
+// abort stops the chain and leaves err for ErrorHandler
+func abort(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+}
+
+// errorStatus is the HTTP status for err
+func errorStatus(err error) int {
+	status, _ := errorResponse(err)
+	return status
+}
+
+// errorResponse maps err to its status and body. Errors that are neither an
+// apiError nor a usecase.Error are internal and their text is not shown.
+func errorResponse(err error) (int, model.ErrorBody) {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status, model.ErrorBody{Code: apiErr.Code, Message: apiErr.Message, Details: apiErr.Details}
+	}
+
+	var domainErr *usecase.Error
+	if errors.As(err, &domainErr) {
+		if status, ok := errorStatuses[domainErr.Kind]; ok {
+			return status, model.ErrorBody{Code: domainErr.Code, Message: domainErr.Message}
+		}
+	}
+
+	return http.StatusInternalServerError, model.ErrorBody{Code: codeInternal, Message: "internal server error"}
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
+
+func (suite *ErrorsTestSuite) TestErrorStatus() {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{
+			name:     "invalid credentials",
+			err:      usecase.ErrInvalidCredentials,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "locked out",
+			err:      &usecase.LoginLockedError{RetryAfter: time.Minute},
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:     "forbidden",
+			err:      usecase.NewError(usecase.KindForbidden, "forbidden", "forbidden"),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "wrapped not found",
+			err:      fmt.Errorf("get task: %w", usecase.ErrNotFound),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "username taken",
+			err:      usecase.ErrUsernameTaken,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "no update data",
+			err:      usecase.ErrNoUpdateData,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "unknown error",
+			err:      errors.New("some error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.Equal(tt.wantCode, errorStatus(tt.err))
+		})
+	}
+}
+
+func (suite *ErrorsTestSuite) TestErrorHandler() {
+	app := gin.New()
+	app.Use(RequestID(), ErrorHandler())
+	app.POST("/test", func(c *gin.Context) {
+		var body model.APITokenBodyParam
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(bindError(c, err))
+			return
+		}
+		switch body.Name {
+		case "locked":
+			c.Error(&usecase.LoginLockedError{RetryAfter: 90 * time.Second})
+		case "missing":
+			c.Error(usecase.ErrNotFound)
+		default:
+			c.Error(errors.New("connection reset by mongo"))
+		}
+	})
+
+	tests := []struct {
+		name           string
+		body           string
+		requestID      string
+		wantCode       int
+		wantBody       model.ErrorBody
+		wantRetryAfter string
+	}{
+		{
+			name:     "validation failed",
+			body:     `{"name": "", "scopes": ["tasks:admin"], "expires_in_days": 0}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: model.ErrorBody{
+				Code:    codeValidationFailed,
+				Message: "name is a required field, scopes[0] must be one of [tasks:read tasks:write]",
+				Details: []model.FieldError{
+					{Field: "name", Code: "required", Message: "name is a required field"},
+					{Field: "scopes[0]", Code: "oneof", Param: "tasks:read tasks:write", Message: "scopes[0] must be one of [tasks:read tasks:write]"},
+				},
+			},
+		},
+		{
+			name:     "wrong type",
+			body:     `{"name": "ci", "scopes": ["tasks:read"], "expires_in_days": "ten"}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: model.ErrorBody{
+				Code:    codeValidationFailed,
+				Message: "expires_in_days must be a number",
+				Details: []model.FieldError{
+					{Field: "expires_in_days", Code: "type", Message: "expires_in_days must be a number"},
+				},
+			},
+		},
+		{
+			name:     "malformed json",
+			body:     `{"name": `,
+			wantCode: http.StatusBadRequest,
+			wantBody: model.ErrorBody{Code: codeInvalidRequest, Message: "request body is not valid JSON"},
+		},
+		{
+			name:     "domain error",
+			body:     `{"name": "missing", "scopes": ["tasks:read"]}`,
+			wantCode: http.StatusNotFound,
+			wantBody: model.ErrorBody{Code: "not_found", Message: "data not found"},
+		},
+		{
+			name:           "locked out",
+			body:           `{"name": "locked", "scopes": ["tasks:read"]}`,
+			wantCode:       http.StatusTooManyRequests,
+			wantBody:       model.ErrorBody{Code: "too_many_login_attempts", Message: "too many failed login attempts, try again later"},
+			wantRetryAfter: "90",
+		},
+		{
+			name:      "internal error is hidden",
+			body:      `{"name": "other", "scopes": ["tasks:read"]}`,
+			requestID: "req-123",
+			wantCode:  http.StatusInternalServerError,
+			wantBody:  model.ErrorBody{Code: codeInternal, Message: "internal server error", RequestID: "req-123"},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.requestID != "" {
+				req.Header.Set(requestIDHeader, tt.requestID)
+			}
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+			suite.Equal(tt.wantRetryAfter, w.Header().Get("Retry-After"))
+
+			var res model.ErrorResponse
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+			suite.Equal(w.Header().Get(requestIDHeader), res.Error.RequestID)
+			if tt.wantBody.RequestID == "" {
+				tt.wantBody.RequestID = res.Error.RequestID
+			}
+			suite.Equal(tt.wantBody, res.Error)
+		})
+	}
+}
+
+func (suite *ErrorsTestSuite) TestRequestID() {
+	app := gin.New()
+	app.Use(RequestID())
+	app.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name      string
+		requestID string
+		wantKept  bool
+	}{
+		{
+			name:      "kept from the client",
+			requestID: "abc-123_DEF.4",
+			wantKept:  true,
+		},
+		{
+			name:      "replaced when unsafe",
+			requestID: "abc\r\nX-Injected: 1",
+		},
+		{
+			name: "generated when missing",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.requestID != "" {
+				req.Header.Set(requestIDHeader, tt.requestID)
+			}
+			app.ServeHTTP(w, req)
+
+			got := w.Header().Get(requestIDHeader)
+			if tt.wantKept {
+				suite.Equal(tt.requestID, got)
+			} else {
+				suite.Len(got, 32)
+			}
+		})
+	}
+}
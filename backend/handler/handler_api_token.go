@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"net/http"
+)
+
+// tokens can only be managed from a login session, a personal access token
+// can't mint or revoke other tokens
+func registerAPITokenHandler(route *gin.Engine) {
+	token := route.Group("/tokens", AuthMiddleware(), SessionOnly())
+	token.GET("", InstanceHandler.listAPITokens)
+	token.POST("", InstanceHandler.createAPIToken)
+	token.DELETE("/:id", InstanceHandler.revokeAPIToken)
+}
+
+func (i MainInstance) listAPITokens(c *gin.Context) {
+	res, err := i.apiTokenUseCase.ListTokens(c, principal(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": res,
+	})
+}
+
+func (i MainInstance) createAPIToken(c *gin.Context) {
+	var body model.APITokenBodyParam
+	err := c.ShouldBind(&body)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.apiTokenUseCase.CreateToken(c, principal(c), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) revokeAPIToken(c *gin.Context) {
+	var param model.APITokenGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	err = i.apiTokenUseCase.RevokeToken(c, principal(c), param.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/handler/interfaces/mocks"
+	"github.com/hendrihmwn/crud-task-backend/helper"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type APITokenHandlerTestSuite struct {
+	suite.Suite
+	Module              *MainInstance
+	Config              helper.Config
+	APITokenUseCaseMock *mocks.APITokenUseCase
+}
+
+func (suite *APITokenHandlerTestSuite) SetupTest() {
+	suite.APITokenUseCaseMock = mocks.NewAPITokenUseCase(suite.T())
+	suite.Module = &MainInstance{
+		config:          helper.Config{},
+		apiTokenUseCase: suite.APITokenUseCaseMock,
+	}
+}
+
+func TestAPITokenHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(APITokenHandlerTestSuite))
+}
+
+func (suite *APITokenHandlerTestSuite) TestListAPITokensHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test", MockToken(), suite.Module.listAPITokens)
+
+	tests := []struct {
+		name     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name: "error - list",
+			mock: func() {
+				suite.APITokenUseCaseMock.EXPECT().ListTokens(mock.Anything, testPrincipal).
+					Return(nil, errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			mock: func() {
+				suite.APITokenUseCaseMock.EXPECT().ListTokens(mock.Anything, testPrincipal).
+					Return([]model.APITokenResponse{{
+						ID:        "XXX",
+						Name:      "ci",
+						Scopes:    []string{model.ScopeTasksRead},
+						Prefix:    "pat_abcdefgh",
+						CreatedAt: time.Now(),
+					}}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *APITokenHandlerTestSuite) TestCreateAPITokenHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test", MockToken(), suite.Module.createAPIToken)
+
+	tests := []struct {
+		name     string
+		args     model.APITokenBodyParam
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad request",
+			args:     model.APITokenBodyParam{},
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - unknown scope",
+			args: model.APITokenBodyParam{
+				Name:   "ci",
+				Scopes: []string{"tasks:admin"},
+			},
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - create",
+			args: model.APITokenBodyParam{
+				Name:   "ci",
+				Scopes: []string{model.ScopeTasksRead},
+			},
+			mock: func() {
+				suite.APITokenUseCaseMock.EXPECT().CreateToken(mock.Anything, testPrincipal, mock.Anything).
+					Return(nil, errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			args: model.APITokenBodyParam{
+				Name:   "ci",
+				Scopes: []string{model.ScopeTasksRead, model.ScopeTasksWrite},
+			},
+			mock: func() {
+				suite.APITokenUseCaseMock.EXPECT().CreateToken(mock.Anything, testPrincipal, mock.Anything).
+					Return(&model.APITokenResponse{
+						ID:     "XXX",
+						Name:   "ci",
+						Token:  "pat_xxx",
+						Scopes: []string{model.ScopeTasksRead, model.ScopeTasksWrite},
+					}, nil).Once()
+			},
+			wantCode: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			jsonBody, _ := json.Marshal(tt.args)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *APITokenHandlerTestSuite) TestRevokeAPITokenHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.DELETE("/test/:id", MockToken(), suite.Module.revokeAPIToken)
+
+	tests := []struct {
+		name     string
+		args     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name: "error - not found",
+			args: "68fc6a818c54acf4a737d7ab",
+			mock: func() {
+				suite.APITokenUseCaseMock.EXPECT().RevokeToken(mock.Anything, testPrincipal, "68fc6a818c54acf4a737d7ab").
+					Return(usecase.ErrNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "error - revoke",
+			args: "68fc6a818c54acf4a737d7ab",
+			mock: func() {
+				suite.APITokenUseCaseMock.EXPECT().RevokeToken(mock.Anything, testPrincipal, "68fc6a818c54acf4a737d7ab").
+					Return(errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			args: "68fc6a818c54acf4a737d7ab",
+			mock: func() {
+				suite.APITokenUseCaseMock.EXPECT().RevokeToken(mock.Anything, testPrincipal, "68fc6a818c54acf4a737d7ab").
+					Return(nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/test/%s", tt.args), nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
@@ -7,7 +7,11 @@ import (
 )
 
 func registerAuthHandler(route *gin.Engine) {
-	route.POST("/login", ValidationErrorHandler(model.LoginParam{}), InstanceHandler.login)
+	route.POST("/login", InstanceHandler.login)
+	route.POST("/register", InstanceHandler.register)
+	route.POST("/token/refresh", InstanceHandler.refreshToken)
+	route.POST("/logout", InstanceHandler.logout)
+	route.GET("/.well-known/jwks.json", InstanceHandler.jwks)
 }
 
 func (i MainInstance) login(c *gin.Context) {
@@ -15,14 +19,15 @@ func (i MainInstance) login(c *gin.Context) {
 
 	err := c.ShouldBind(&param)
 	if err != nil {
-		errMessage := FormatValidationError(err, param)
-		c.JSON(http.StatusBadRequest, gin.H{"error": errMessage})
+		c.Error(bindError(c, err))
 		return
 	}
 
+	param.UserAgent = c.Request.UserAgent()
+	param.IP = c.ClientIP()
 	res, err := i.authUseCase.Login(c, param)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -30,3 +35,68 @@ func (i MainInstance) login(c *gin.Context) {
 		"data": res,
 	})
 }
+
+func (i MainInstance) register(c *gin.Context) {
+	var param model.RegisterParam
+
+	err := c.ShouldBind(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	res, err := i.authUseCase.Register(c, param)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": res,
+	})
+}
+
+func (i MainInstance) refreshToken(c *gin.Context) {
+	var param model.RefreshTokenParam
+
+	err := c.ShouldBind(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	param.UserAgent = c.Request.UserAgent()
+	param.IP = c.ClientIP()
+	res, err := i.authUseCase.Refresh(c, param)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": res,
+	})
+}
+
+func (i MainInstance) logout(c *gin.Context) {
+	var param model.RefreshTokenParam
+
+	err := c.ShouldBind(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	err = i.authUseCase.Logout(c, param)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (i MainInstance) jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, i.config.SigningKeys().JWKS())
+}
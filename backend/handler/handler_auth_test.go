@@ -2,17 +2,23 @@ package handler
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/hendrihmwn/crud-task-backend/handler/interfaces/mocks"
 	"github.com/hendrihmwn/crud-task-backend/helper"
 	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 type AuthHandlerTestSuite struct {
@@ -36,13 +42,15 @@ func TestAuthHandlerTestSuite(t *testing.T) {
 
 func (suite *AuthHandlerTestSuite) TestLoginAuthHandler() {
 	app := gin.New()
+	app.Use(ErrorHandler())
 	app.POST("/test", suite.Module.login)
 
 	tests := []struct {
-		name     string
-		args     model.LoginParam
-		mock     func()
-		wantCode int
+		name           string
+		args           model.LoginParam
+		mock           func()
+		wantCode       int
+		wantRetryAfter string
 	}{
 		{
 			name:     "error - bad request",
@@ -62,6 +70,31 @@ func (suite *AuthHandlerTestSuite) TestLoginAuthHandler() {
 			},
 			wantCode: http.StatusInternalServerError,
 		},
+		{
+			name: "error - invalid credentials",
+			args: model.LoginParam{
+				Username: "admin",
+				Password: "123",
+			},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Login(mock.Anything, mock.Anything).
+					Return(model.AuthResponse{}, usecase.ErrInvalidCredentials).Once()
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "error - locked out",
+			args: model.LoginParam{
+				Username: "admin",
+				Password: "123",
+			},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Login(mock.Anything, mock.Anything).
+					Return(model.AuthResponse{}, &usecase.LoginLockedError{RetryAfter: 1500 * time.Millisecond}).Once()
+			},
+			wantCode:       http.StatusTooManyRequests,
+			wantRetryAfter: "2",
+		},
 		{
 			name: "success",
 			args: model.LoginParam{
@@ -89,6 +122,249 @@ func (suite *AuthHandlerTestSuite) TestLoginAuthHandler() {
 			req.Header.Set("Content-Type", "application/json")
 			app.ServeHTTP(w, req)
 			suite.Equal(tt.wantCode, w.Code)
+			suite.Equal(tt.wantRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func (suite *AuthHandlerTestSuite) TestRegisterAuthHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test", suite.Module.register)
+
+	tests := []struct {
+		name     string
+		args     model.RegisterParam
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad request",
+			args:     model.RegisterParam{},
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - username taken",
+			args: model.RegisterParam{
+				Username: "admin",
+				Password: "password",
+			},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Register(mock.Anything, mock.Anything).
+					Return(model.UserResponse{}, usecase.ErrUsernameTaken).Once()
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "error - password too long",
+			args: model.RegisterParam{
+				Username: "admin",
+				Password: strings.Repeat("é", 40),
+			},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Register(mock.Anything, mock.Anything).
+					Return(model.UserResponse{}, usecase.ErrPasswordTooLong).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "error - register",
+			args: model.RegisterParam{
+				Username: "admin",
+				Password: "password",
+			},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Register(mock.Anything, mock.Anything).
+					Return(model.UserResponse{}, errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			args: model.RegisterParam{
+				Username: "admin",
+				Password: "password",
+			},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Register(mock.Anything, mock.Anything).
+					Return(model.UserResponse{
+						ID:       "XXX",
+						Username: "admin",
+					}, nil).Once()
+			},
+			wantCode: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			jsonBody, _ := json.Marshal(tt.args)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *AuthHandlerTestSuite) TestRefreshTokenAuthHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test", suite.Module.refreshToken)
+
+	tests := []struct {
+		name     string
+		args     model.RefreshTokenParam
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad request",
+			args:     model.RefreshTokenParam{},
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - reused token",
+			args: model.RefreshTokenParam{RefreshToken: "xxx"},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Refresh(mock.Anything, mock.Anything).
+					Return(model.AuthResponse{}, usecase.ErrRefreshTokenReused).Once()
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "error - refresh",
+			args: model.RefreshTokenParam{RefreshToken: "xxx"},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Refresh(mock.Anything, mock.Anything).
+					Return(model.AuthResponse{}, errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			args: model.RefreshTokenParam{RefreshToken: "xxx"},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Refresh(mock.Anything, mock.Anything).
+					Return(model.AuthResponse{
+						Token:        "xxx",
+						RefreshToken: "yyy",
+					}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			jsonBody, _ := json.Marshal(tt.args)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *AuthHandlerTestSuite) TestLogoutAuthHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test", suite.Module.logout)
+
+	tests := []struct {
+		name     string
+		args     model.RefreshTokenParam
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad request",
+			args:     model.RefreshTokenParam{},
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - logout",
+			args: model.RefreshTokenParam{RefreshToken: "xxx"},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Logout(mock.Anything, mock.Anything).
+					Return(errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			args: model.RefreshTokenParam{RefreshToken: "xxx"},
+			mock: func() {
+				suite.AuthUseCaseMock.EXPECT().Logout(mock.Anything, mock.Anything).
+					Return(nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			jsonBody, _ := json.Marshal(tt.args)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *AuthHandlerTestSuite) TestJWKSAuthHandler() {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keys, _ := helper.NewTokenKeys("k1", &helper.TokenKey{ID: "k1", Method: jwt.SigningMethodRS256, Private: key, Public: &key.PublicKey})
+
+	tests := []struct {
+		name     string
+		config   helper.Config
+		wantKids []string
+	}{
+		{
+			name:     "success - hmac publishes nothing",
+			config:   helper.Config{JWTSecret: "secret"},
+			wantKids: nil,
+		},
+		{
+			name:     "success - asymmetric keys",
+			config:   helper.Config{TokenKeys: keys},
+			wantKids: []string{"k1"},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			module := MainInstance{config: tt.config}
+			app := gin.New()
+			app.Use(ErrorHandler())
+			app.GET("/test", module.jwks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			app.ServeHTTP(w, req)
+			suite.Equal(http.StatusOK, w.Code)
+
+			var set model.JWKSet
+			suite.NoError(json.Unmarshal(w.Body.Bytes(), &set))
+			var kids []string
+			for _, k := range set.Keys {
+				kids = append(kids, k.Kid)
+			}
+			suite.Equal(tt.wantKids, kids)
 		})
 	}
 }
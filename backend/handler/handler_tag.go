@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"net/http"
+)
+
+func registerTagHandler(route *gin.Engine) {
+	tag := route.Group("/tags", AuthMiddleware(), TaskAuthorization())
+	tag.GET("", RequireScope(model.ScopeTasksRead), InstanceHandler.listTags)
+	tag.POST("/:tag/rename", RequireScope(model.ScopeTasksWrite), InstanceHandler.renameTag)
+}
+
+func (i MainInstance) listTags(c *gin.Context) {
+	res, err := i.taskUseCase.ListTags(c, principal(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": res,
+	})
+}
+
+func (i MainInstance) renameTag(c *gin.Context) {
+	var param model.TagParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	var body model.TagRenameParam
+	err = c.ShouldBind(&body)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskUseCase.RenameTag(c, principal(c), param.Name, body.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (suite *TaskHandlerTestSuite) TestListTagsHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test", MockToken(), suite.Module.listTags)
+
+	tests := []struct {
+		name     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name: "error - list tags",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ListTags(mock.Anything, testPrincipal).
+					Return(nil, errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ListTags(mock.Anything, testPrincipal).
+					Return([]model.TagCount{{Name: "backend", Count: 2}}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *TaskHandlerTestSuite) TestRenameTagHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test/:tag/rename", MockToken(), suite.Module.renameTag)
+
+	tests := []struct {
+		name     string
+		tag      string
+		body     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad tag",
+			tag:      "not%20a%20tag",
+			body:     `{"name": "defect"}`,
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "error - missing name",
+			tag:      "bug",
+			body:     `{}`,
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - unknown tag",
+			tag:  "bug",
+			body: `{"name": "defect"}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RenameTag(mock.Anything, testPrincipal, "bug", "defect").
+					Return(nil, usecase.ErrTagNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "success",
+			tag:  "bug",
+			body: `{"name": "defect"}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RenameTag(mock.Anything, testPrincipal, "bug", "defect").
+					Return(&model.TagRenameResponse{From: "bug", To: "defect", Updated: 2}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/test/%s/rename", tt.tag), bytes.NewBufferString(tt.body))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
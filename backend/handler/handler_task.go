@@ -2,30 +2,61 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 func registerTaskHandler(route *gin.Engine) {
-	task := route.Group("/tasks", AuthMiddleware())
-	task.GET("", InstanceHandler.listTask)
-	task.GET("/:id", InstanceHandler.getTask)
-	task.POST("", InstanceHandler.createTask)
-	task.PUT("/:id", InstanceHandler.updateTask)
-	task.DELETE("/:id", InstanceHandler.deleteTask)
+	task := route.Group("/tasks", AuthMiddleware(), TaskAuthorization())
+	read := RequireScope(model.ScopeTasksRead)
+	write := RequireScope(model.ScopeTasksWrite)
+	task.GET("", read, InstanceHandler.listTask)
+	task.GET("/trash", read, InstanceHandler.listTrash)
+	task.GET("/:id", read, InstanceHandler.getTask)
+	task.POST("", write, InstanceHandler.createTask)
+	task.PUT("/:id", write, InstanceHandler.updateTask)
+	task.PATCH("/:id", write, InstanceHandler.patchTask)
+	task.DELETE("/:id", write, InstanceHandler.deleteTask)
+	task.POST("/:id/restore", write, InstanceHandler.restoreTask)
+	task.GET("/:id/history", read, InstanceHandler.taskHistory)
+	task.POST("/:id/checklist", write, InstanceHandler.addChecklistItem)
+	task.PUT("/:id/checklist/order", write, InstanceHandler.reorderChecklist)
+	task.PATCH("/:id/checklist/:item_id", write, InstanceHandler.updateChecklistItem)
+	task.DELETE("/:id/checklist/:item_id", write, InstanceHandler.removeChecklistItem)
+	task.POST("/:id/dependencies", write, InstanceHandler.addDependency)
+	task.DELETE("/:id/dependencies/:blocker_id", write, InstanceHandler.removeDependency)
 }
 
 func (i MainInstance) listTask(c *gin.Context) {
+	// validated once the view has filled in what the query leaves out
 	var param model.TaskListParam
-	err := c.ShouldBind(&param)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := binding.MapFormWithTag(&param, c.Request.URL.Query(), "form"); err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+	if primitive.IsValidObjectID(param.View) {
+		if err := i.applyView(c, &param); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+	if err := binding.Validator.ValidateStruct(&param); err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	if param.Page == 0 {
+		i.listTaskCursor(c, param)
 		return
 	}
 
-	res, size, err := i.taskUseCase.ListTask(c, param)
+	res, size, err := i.taskUseCase.ListTask(c, principal(c), param)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -39,24 +70,38 @@ func (i MainInstance) listTask(c *gin.Context) {
 	})
 }
 
+// listTaskCursor answers GET /tasks without page, a page at a time by cursor
+func (i MainInstance) listTaskCursor(c *gin.Context, param model.TaskListParam) {
+	res, next, err := i.taskUseCase.ListTaskCursor(c, principal(c), param)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"meta": model.TaskCursorMeta{
+			Limit:      int(param.Limit),
+			NextCursor: next,
+		},
+		"data": res,
+	})
+}
+
 func (i MainInstance) getTask(c *gin.Context) {
 	var param model.TaskGetParam
 	err := c.ShouldBindUri(&param)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindError(c, err))
 		return
 	}
 
-	data, err := i.taskUseCase.GetTask(c, param.ID)
-	if data == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "data not found"})
-		return
-	}
+	data, err := i.taskUseCase.GetTask(c, principal(c), param.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", etag(data.Version))
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -66,16 +111,17 @@ func (i MainInstance) createTask(c *gin.Context) {
 	var body model.TaskBodyParam
 	err := c.ShouldBind(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindError(c, err))
 		return
 	}
 
-	data, err := i.taskUseCase.CreateTask(c, body)
+	data, err := i.taskUseCase.CreateTask(c, principal(c), body)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", etag(data.Version))
 	c.JSON(http.StatusCreated, gin.H{
 		"data": data,
 	})
@@ -85,27 +131,65 @@ func (i MainInstance) updateTask(c *gin.Context) {
 	var param model.TaskGetParam
 	err := c.ShouldBindUri(&param)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	var body model.TaskBodyParam
 	err = c.ShouldBind(&body)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskUseCase.UpdateTask(c, principal(c), param.ID, version, body)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	data, err := i.taskUseCase.UpdateTask(c, param.ID, body)
-	if data == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "data not found"})
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// patchTask takes an application/merge-patch+json body, plain
+// application/json is read the same way
+func (i MainInstance) patchTask(c *gin.Context) {
+	var param model.TaskGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var patch model.TaskPatchParam
+	err = c.ShouldBindJSON(&patch)
+	if err != nil {
+		c.Error(bindError(c, err))
 		return
 	}
+
+	data, err := i.taskUseCase.PatchTask(c, principal(c), param.ID, version, patch)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	c.Header("ETag", etag(data.Version))
 	c.JSON(http.StatusOK, gin.H{
 		"data": data,
 	})
@@ -115,15 +199,119 @@ func (i MainInstance) deleteTask(c *gin.Context) {
 	var param model.TaskGetParam
 	err := c.ShouldBindUri(&param)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	err = i.taskUseCase.DeleteTask(c, param.ID)
+	err = i.taskUseCase.DeleteTask(c, principal(c), param.ID, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+func (i MainInstance) listTrash(c *gin.Context) {
+	var param model.TaskTrashParam
+	err := c.ShouldBind(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	res, size, err := i.taskUseCase.ListTrash(c, principal(c), param)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"meta": model.TaskMeta{
+			Limit: int(param.Limit),
+			Page:  int(param.Page),
+			Total: size,
+		},
+		"data": res,
+	})
+}
+
+func (i MainInstance) restoreTask(c *gin.Context) {
+	var param model.TaskGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskUseCase.RestoreTask(c, principal(c), param.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) taskHistory(c *gin.Context) {
+	var param model.TaskGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	var query model.TaskHistoryParam
+	err = c.ShouldBindQuery(&query)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	res, size, err := i.taskUseCase.TaskHistory(c, principal(c), param.ID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"meta": model.TaskMeta{
+			Limit: int(query.Limit),
+			Page:  int(query.Page),
+			Total: size,
+		},
+		"data": res,
+	})
+}
+
+// etag is the entity tag of a task version
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatch is the task version the client expects from If-Match. Without the
+// header, or with *, it is 0 and the version is not checked. Weak tags never
+// match, as If-Match uses the strong comparison.
+func ifMatch(c *gin.Context) (int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, errBadPrecondition
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, errBadPrecondition
+	}
+	return version, nil
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"net/http"
+)
+
+// the checklist handlers answer with the whole task and its new ETag, and
+// take If-Match like the other task writes
+
+func (i MainInstance) addChecklistItem(c *gin.Context) {
+	var param model.TaskGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body model.ChecklistItemBodyParam
+	err = c.ShouldBind(&body)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskUseCase.AddChecklistItem(c, principal(c), param.ID, version, body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) updateChecklistItem(c *gin.Context) {
+	var param model.ChecklistItemParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var patch model.ChecklistItemPatchParam
+	err = c.ShouldBindJSON(&patch)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskUseCase.UpdateChecklistItem(c, principal(c), param.TaskID, param.ItemID, version, patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) reorderChecklist(c *gin.Context) {
+	var param model.TaskGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body model.ChecklistOrderParam
+	err = c.ShouldBind(&body)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskUseCase.ReorderChecklist(c, principal(c), param.ID, version, body.IDs)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) removeChecklistItem(c *gin.Context) {
+	var param model.ChecklistItemParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	data, err := i.taskUseCase.RemoveChecklistItem(c, principal(c), param.TaskID, param.ItemID, version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (suite *TaskHandlerTestSuite) TestChecklistHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test/:id/checklist", MockToken(), suite.Module.addChecklistItem)
+	app.PUT("/test/:id/checklist/order", MockToken(), suite.Module.reorderChecklist)
+	app.PATCH("/test/:id/checklist/:item_id", MockToken(), suite.Module.updateChecklistItem)
+	app.DELETE("/test/:id/checklist/:item_id", MockToken(), suite.Module.removeChecklistItem)
+
+	const (
+		taskID = "68fc6a818c54acf4a737d7ab"
+		itemID = "68fc6a818c54acf4a737d7b1"
+	)
+	done := true
+	task := &model.TaskResponse{ID: taskID, Version: 4, Progress: &model.TaskProgress{Done: 1, Total: 2}}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		ifMatch  string
+		mock     func()
+		wantCode int
+		wantETag string
+	}{
+		{
+			name:     "add - blank text",
+			method:   "POST",
+			path:     "/test/" + taskID + "/checklist",
+			body:     `{"text": " "}`,
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:    "add - success",
+			method:  "POST",
+			path:    "/test/" + taskID + "/checklist",
+			body:    `{"text": "review"}`,
+			ifMatch: `"3"`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().AddChecklistItem(mock.Anything, testPrincipal, taskID, int64(3), model.ChecklistItemBodyParam{Text: "review"}).
+					Return(task, nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantETag: `"4"`,
+		},
+		{
+			name:     "update - bad item id",
+			method:   "PATCH",
+			path:     "/test/" + taskID + "/checklist/xxx",
+			body:     `{"done": true}`,
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "update - unknown item",
+			method: "PATCH",
+			path:   "/test/" + taskID + "/checklist/" + itemID,
+			body:   `{"done": true}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().UpdateChecklistItem(mock.Anything, testPrincipal, taskID, itemID, int64(0), model.ChecklistItemPatchParam{Done: &done}).
+					Return(nil, usecase.ErrChecklistItemNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "reorder - bad id",
+			method:   "PUT",
+			path:     "/test/" + taskID + "/checklist/order",
+			body:     `{"ids": ["xxx"]}`,
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "reorder - not every item",
+			method: "PUT",
+			path:   "/test/" + taskID + "/checklist/order",
+			body:   `{"ids": ["` + itemID + `"]}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ReorderChecklist(mock.Anything, testPrincipal, taskID, int64(0), []string{itemID}).
+					Return(nil, usecase.ErrInvalidChecklistOrder).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "remove - stale version",
+			method:  "DELETE",
+			path:    "/test/" + taskID + "/checklist/" + itemID,
+			ifMatch: `"2"`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RemoveChecklistItem(mock.Anything, testPrincipal, taskID, itemID, int64(2)).
+					Return(nil, usecase.ErrVersionMismatch).Once()
+			},
+			wantCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "remove - success",
+			method: "DELETE",
+			path:   "/test/" + taskID + "/checklist/" + itemID,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RemoveChecklistItem(mock.Anything, testPrincipal, taskID, itemID, int64(0)).
+					Return(task, nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantETag: `"4"`,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+			suite.Equal(tt.wantETag, w.Header().Get("ETag"))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"net/http"
+)
+
+func (i MainInstance) addDependency(c *gin.Context) {
+	var param model.TaskGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body model.TaskDependencyBodyParam
+	err = c.ShouldBind(&body)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskUseCase.AddDependency(c, principal(c), param.ID, version, body.BlockedBy)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) removeDependency(c *gin.Context) {
+	var param model.TaskDependencyParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	data, err := i.taskUseCase.RemoveDependency(c, principal(c), param.TaskID, param.BlockerID, version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", etag(data.Version))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
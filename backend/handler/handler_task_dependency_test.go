@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (suite *TaskHandlerTestSuite) TestDependencyHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test/:id/dependencies", MockToken(), suite.Module.addDependency)
+	app.DELETE("/test/:id/dependencies/:blocker_id", MockToken(), suite.Module.removeDependency)
+
+	const (
+		taskID    = "68fc6a818c54acf4a737d7ab"
+		blockerID = "68fc6a818c54acf4a737d7b2"
+	)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "add - bad blocker id",
+			method:   "POST",
+			path:     "/test/" + taskID + "/dependencies",
+			body:     `{"blocked_by": "xxx"}`,
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "add - cycle",
+			method: "POST",
+			path:   "/test/" + taskID + "/dependencies",
+			body:   `{"blocked_by": "` + blockerID + `"}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().AddDependency(mock.Anything, testPrincipal, taskID, int64(0), blockerID).
+					Return(nil, usecase.ErrDependencyCycle).Once()
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:   "add - success",
+			method: "POST",
+			path:   "/test/" + taskID + "/dependencies",
+			body:   `{"blocked_by": "` + blockerID + `"}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().AddDependency(mock.Anything, testPrincipal, taskID, int64(0), blockerID).
+					Return(&model.TaskResponse{ID: taskID, BlockedBy: []string{blockerID}, Version: 2}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "remove - not blocked by it",
+			method: "DELETE",
+			path:   "/test/" + taskID + "/dependencies/" + blockerID,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RemoveDependency(mock.Anything, testPrincipal, taskID, blockerID, int64(0)).
+					Return(nil, usecase.ErrDependencyNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "remove - success",
+			method: "DELETE",
+			path:   "/test/" + taskID + "/dependencies/" + blockerID,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RemoveDependency(mock.Anything, testPrincipal, taskID, blockerID, int64(0)).
+					Return(&model.TaskResponse{ID: taskID, Version: 3}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
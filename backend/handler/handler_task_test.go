@@ -9,6 +9,7 @@ import (
 	"github.com/hendrihmwn/crud-task-backend/handler/interfaces/mocks"
 	"github.com/hendrihmwn/crud-task-backend/helper"
 	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"net/http"
@@ -17,6 +18,8 @@ import (
 	"time"
 )
 
+var testPrincipal = model.Principal{UserID: "test-user", Role: model.RoleMember}
+
 type TaskHandlerTestSuite struct {
 	suite.Suite
 	Module          *MainInstance
@@ -38,6 +41,7 @@ func TestTaskHandlerTestSuite(t *testing.T) {
 
 func (suite *TaskHandlerTestSuite) TestListTaskHandler() {
 	app := gin.New()
+	app.Use(ErrorHandler())
 	app.GET("/test", MockToken(), suite.Module.listTask)
 
 	tests := []struct {
@@ -59,7 +63,7 @@ func (suite *TaskHandlerTestSuite) TestListTaskHandler() {
 				Page:  1,
 			},
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().ListTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().ListTask(mock.Anything, testPrincipal, mock.Anything).
 					Return([]model.TaskResponse{}, 0, errors.New("some error")).Once()
 			},
 			wantCode: http.StatusInternalServerError,
@@ -71,7 +75,7 @@ func (suite *TaskHandlerTestSuite) TestListTaskHandler() {
 				Page:  1,
 			},
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().ListTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().ListTask(mock.Anything, testPrincipal, mock.Anything).
 					Return([]model.TaskResponse{{
 						ID:          "XXX",
 						Title:       "title",
@@ -96,8 +100,72 @@ func (suite *TaskHandlerTestSuite) TestListTaskHandler() {
 	}
 }
 
+func (suite *TaskHandlerTestSuite) TestListTaskCursorHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test", MockToken(), suite.Module.listTask)
+
+	tests := []struct {
+		name     string
+		query    string
+		mock     func()
+		wantCode int
+		wantMeta model.TaskCursorMeta
+	}{
+		{
+			name:  "error - invalid cursor",
+			query: "limit=10&cursor=xxx",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ListTaskCursor(mock.Anything, testPrincipal, model.TaskListParam{Limit: 10, Cursor: "xxx"}).
+					Return(nil, "", usecase.ErrInvalidCursor).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "success - first page",
+			query: "limit=10",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ListTaskCursor(mock.Anything, testPrincipal, model.TaskListParam{Limit: 10}).
+					Return([]model.TaskResponse{{ID: "XXX"}}, "next", nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantMeta: model.TaskCursorMeta{Limit: 10, NextCursor: "next"},
+		},
+		{
+			name:  "success - last page",
+			query: "limit=10&cursor=next",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ListTaskCursor(mock.Anything, testPrincipal, model.TaskListParam{Limit: 10, Cursor: "next"}).
+					Return([]model.TaskResponse{{ID: "YYY"}}, "", nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantMeta: model.TaskCursorMeta{Limit: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test?"+tt.query, nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+
+			if tt.wantCode == http.StatusOK {
+				var res struct {
+					Meta model.TaskCursorMeta `json:"meta"`
+				}
+				suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+				suite.Equal(tt.wantMeta, res.Meta)
+			}
+		})
+	}
+}
+
 func (suite *TaskHandlerTestSuite) TestGetTaskHandler() {
 	app := gin.New()
+	app.Use(ErrorHandler())
 	app.GET("/test/:id", MockToken(), suite.Module.getTask)
 
 	tests := []struct {
@@ -110,7 +178,7 @@ func (suite *TaskHandlerTestSuite) TestGetTaskHandler() {
 			name: "error - get",
 			args: "68fc6a818c54acf4a737d7ab",
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, testPrincipal, mock.Anything).
 					Return(&model.TaskResponse{}, errors.New("some error")).Once()
 			},
 			wantCode: http.StatusInternalServerError,
@@ -119,8 +187,8 @@ func (suite *TaskHandlerTestSuite) TestGetTaskHandler() {
 			name: "error - data not found",
 			args: "68fc6a818c54acf4a737d7ab",
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, mock.Anything).
-					Return(nil, nil).Once()
+				suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, testPrincipal, mock.Anything).
+					Return(nil, usecase.ErrNotFound).Once()
 			},
 			wantCode: http.StatusNotFound,
 		},
@@ -128,7 +196,7 @@ func (suite *TaskHandlerTestSuite) TestGetTaskHandler() {
 			name: "success",
 			args: "68fc6a818c54acf4a737d7ab",
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, testPrincipal, mock.Anything).
 					Return(&model.TaskResponse{
 						ID:          "XXX",
 						Title:       "title",
@@ -155,6 +223,7 @@ func (suite *TaskHandlerTestSuite) TestGetTaskHandler() {
 
 func (suite *TaskHandlerTestSuite) TestCreateTaskHandler() {
 	app := gin.New()
+	app.Use(ErrorHandler())
 	app.POST("/test", MockToken(), suite.Module.createTask)
 
 	tests := []struct {
@@ -178,7 +247,7 @@ func (suite *TaskHandlerTestSuite) TestCreateTaskHandler() {
 				Status:      "backlog",
 			},
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().CreateTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().CreateTask(mock.Anything, testPrincipal, mock.Anything).
 					Return(nil, errors.New("some error")).Once()
 			},
 			wantCode: http.StatusInternalServerError,
@@ -191,7 +260,7 @@ func (suite *TaskHandlerTestSuite) TestCreateTaskHandler() {
 				Status:      "backlog",
 			},
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().CreateTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().CreateTask(mock.Anything, testPrincipal, mock.Anything).
 					Return(&model.TaskResponse{
 						ID:          "XXX",
 						Title:       "title",
@@ -220,6 +289,7 @@ func (suite *TaskHandlerTestSuite) TestCreateTaskHandler() {
 
 func (suite *TaskHandlerTestSuite) TestUpdateTaskHandler() {
 	app := gin.New()
+	app.Use(ErrorHandler())
 	app.PUT("/test/:id", MockToken(), suite.Module.updateTask)
 
 	tests := []struct {
@@ -243,11 +313,48 @@ func (suite *TaskHandlerTestSuite) TestUpdateTaskHandler() {
 				Status:      "backlog",
 			},
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, nil).Once()
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(0), mock.Anything).
+					Return(nil, usecase.ErrNotFound).Once()
 			},
 			wantCode: http.StatusNotFound,
 		},
+		{
+			name: "error - no update data",
+			args: model.TaskBodyParam{
+				Title:       "title",
+				Description: "description",
+				Status:      "backlog",
+			},
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(0), mock.Anything).
+					Return(nil, usecase.ErrNoUpdateData).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "error - unknown status",
+			args: model.TaskBodyParam{
+				Title:       "title",
+				Description: "description",
+				Status:      "in-progress",
+			},
+			mock: func() {
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - invalid status transition",
+			args: model.TaskBodyParam{
+				Title:       "title",
+				Description: "description",
+				Status:      "backlog",
+			},
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(0), mock.Anything).
+					Return(nil, usecase.ErrInvalidStatusTransition.Errorf("a task can't move from completed to backlog")).Once()
+			},
+			wantCode: http.StatusConflict,
+		},
 		{
 			name: "error - update",
 			args: model.TaskBodyParam{
@@ -256,7 +363,7 @@ func (suite *TaskHandlerTestSuite) TestUpdateTaskHandler() {
 				Status:      "backlog",
 			},
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(0), mock.Anything).
 					Return(&model.TaskResponse{}, errors.New("some error")).Once()
 			},
 			wantCode: http.StatusInternalServerError,
@@ -269,7 +376,7 @@ func (suite *TaskHandlerTestSuite) TestUpdateTaskHandler() {
 				Status:      "backlog",
 			},
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(0), mock.Anything).
 					Return(&model.TaskResponse{
 						ID:          "XXX",
 						Title:       "title",
@@ -296,8 +403,82 @@ func (suite *TaskHandlerTestSuite) TestUpdateTaskHandler() {
 	}
 }
 
+func (suite *TaskHandlerTestSuite) TestPatchTaskHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.PATCH("/test/:id", MockToken(), suite.Module.patchTask)
+
+	title := "title"
+	tests := []struct {
+		name     string
+		body     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name: "error - not json",
+			body: `title=title`,
+			mock: func() {
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - blank title",
+			body: `{"title": " "}`,
+			mock: func() {
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - wrong type",
+			body: `{"description": 1}`,
+			mock: func() {
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - bad tag",
+			body: `{"tags": ["backend", "not a tag"]}`,
+			mock: func() {
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - title cleared",
+			body: `{"title": null}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().PatchTask(mock.Anything, testPrincipal, "68f9f4f82464ad9c35d5b699", int64(0), model.TaskPatchParam{Null: []string{"title"}}).
+					Return(nil, usecase.ErrFieldRequired.Errorf("title can't be cleared")).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "success - null description",
+			body: `{"title": "title", "description": null}`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().PatchTask(mock.Anything, testPrincipal, "68f9f4f82464ad9c35d5b699", int64(0), model.TaskPatchParam{Title: &title, Null: []string{"description"}}).
+					Return(&model.TaskResponse{ID: "XXX", Title: "title", Status: "backlog"}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/test/68f9f4f82464ad9c35d5b699", bytes.NewBufferString(tt.body))
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
 func (suite *TaskHandlerTestSuite) TestDeleteTaskHandler() {
 	app := gin.New()
+	app.Use(ErrorHandler())
 	app.DELETE("/test/:id", MockToken(), suite.Module.deleteTask)
 
 	tests := []struct {
@@ -310,16 +491,25 @@ func (suite *TaskHandlerTestSuite) TestDeleteTaskHandler() {
 			name: "error - delete",
 			args: "68fc6a818c54acf4a737d7ab",
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().DeleteTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().DeleteTask(mock.Anything, testPrincipal, mock.Anything, int64(0)).
 					Return(errors.New("some error")).Once()
 			},
 			wantCode: http.StatusInternalServerError,
 		},
+		{
+			name: "error - not found",
+			args: "68fc6a818c54acf4a737d7ab",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().DeleteTask(mock.Anything, testPrincipal, mock.Anything, int64(0)).
+					Return(usecase.ErrNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
 		{
 			name: "success",
 			args: "68fc6a818c54acf4a737d7ab",
 			mock: func() {
-				suite.TaskUseCaseMock.EXPECT().DeleteTask(mock.Anything, mock.Anything).
+				suite.TaskUseCaseMock.EXPECT().DeleteTask(mock.Anything, testPrincipal, mock.Anything, int64(0)).
 					Return(nil).Once()
 			},
 			wantCode: http.StatusOK,
@@ -337,3 +527,350 @@ func (suite *TaskHandlerTestSuite) TestDeleteTaskHandler() {
 		})
 	}
 }
+
+func (suite *TaskHandlerTestSuite) TestListTrashHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test/trash", MockToken(), suite.Module.listTrash)
+
+	deletedAt := time.Now()
+	tests := []struct {
+		name     string
+		args     model.TaskTrashParam
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad request",
+			args:     model.TaskTrashParam{},
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - get list",
+			args: model.TaskTrashParam{Limit: 10, Page: 1},
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ListTrash(mock.Anything, testPrincipal, model.TaskTrashParam{Limit: 10, Page: 1}).
+					Return([]model.TaskResponse{}, 0, errors.New("some error")).Once()
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "success",
+			args: model.TaskTrashParam{Limit: 10, Page: 1},
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().ListTrash(mock.Anything, testPrincipal, model.TaskTrashParam{Limit: 10, Page: 1}).
+					Return([]model.TaskResponse{{
+						ID:        "XXX",
+						Title:     "title",
+						Status:    "backlog",
+						DeletedAt: &deletedAt,
+						DeletedBy: "test-user",
+					}}, 1, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/test/trash?limit=%d&page=%d", tt.args.Limit, tt.args.Page), nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *TaskHandlerTestSuite) TestRestoreTaskHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test/:id/restore", MockToken(), suite.Module.restoreTask)
+
+	tests := []struct {
+		name     string
+		args     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad id",
+			args:     "xxx",
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - not in the trash",
+			args: "68fc6a818c54acf4a737d7ab",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RestoreTask(mock.Anything, testPrincipal, "68fc6a818c54acf4a737d7ab").
+					Return(nil, usecase.ErrNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "success",
+			args: "68fc6a818c54acf4a737d7ab",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().RestoreTask(mock.Anything, testPrincipal, "68fc6a818c54acf4a737d7ab").
+					Return(&model.TaskResponse{ID: "68fc6a818c54acf4a737d7ab", Version: 3}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/test/%s/restore", tt.args), nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *TaskHandlerTestSuite) TestTaskHistoryHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test/:id/history", MockToken(), suite.Module.taskHistory)
+
+	tests := []struct {
+		name     string
+		url      string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad id",
+			url:      "/test/xxx/history?limit=10&page=1",
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "error - missing paging",
+			url:      "/test/68fc6a818c54acf4a737d7ab/history",
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - not found",
+			url:  "/test/68fc6a818c54acf4a737d7ab/history?limit=10&page=1",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().TaskHistory(mock.Anything, testPrincipal, "68fc6a818c54acf4a737d7ab", model.TaskHistoryParam{Limit: 10, Page: 1}).
+					Return(nil, 0, usecase.ErrNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "success",
+			url:  "/test/68fc6a818c54acf4a737d7ab/history?limit=10&page=1",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().TaskHistory(mock.Anything, testPrincipal, "68fc6a818c54acf4a737d7ab", model.TaskHistoryParam{Limit: 10, Page: 1}).
+					Return([]model.TaskEvent{{
+						TaskID:    "68fc6a818c54acf4a737d7ab",
+						Action:    model.TaskEventCreated,
+						ActorID:   "test-user",
+						CreatedAt: time.Now(),
+					}}, 1, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.url, nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *TaskHandlerTestSuite) TestTaskPreconditions() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test/:id", MockToken(), suite.Module.getTask)
+	app.PUT("/test/:id", MockToken(), suite.Module.updateTask)
+
+	body := `{"title": "title", "status": "todo"}`
+	tests := []struct {
+		name     string
+		method   string
+		ifMatch  string
+		mock     func()
+		wantCode int
+		wantETag string
+	}{
+		{
+			name:   "get - etag is the version",
+			method: http.MethodGet,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, testPrincipal, mock.Anything).
+					Return(&model.TaskResponse{ID: "XXX", Version: 4}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantETag: `"4"`,
+		},
+		{
+			name:    "put - if-match is passed as version",
+			method:  http.MethodPut,
+			ifMatch: `"4"`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(4), mock.Anything).
+					Return(&model.TaskResponse{ID: "XXX", Version: 5}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantETag: `"5"`,
+		},
+		{
+			name:    "put - star skips the check",
+			method:  http.MethodPut,
+			ifMatch: "*",
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(0), mock.Anything).
+					Return(&model.TaskResponse{ID: "XXX", Version: 5}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+			wantETag: `"5"`,
+		},
+		{
+			name:    "put - version mismatch",
+			method:  http.MethodPut,
+			ifMatch: `"3"`,
+			mock: func() {
+				suite.TaskUseCaseMock.EXPECT().UpdateTask(mock.Anything, testPrincipal, mock.Anything, int64(3), mock.Anything).
+					Return(nil, usecase.ErrVersionMismatch).Once()
+			},
+			wantCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "put - weak tag never matches",
+			method:  http.MethodPut,
+			ifMatch: `W/"4"`,
+			mock: func() {
+			},
+			wantCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "put - several tags",
+			method:  http.MethodPut,
+			ifMatch: `"4", "5"`,
+			mock: func() {
+			},
+			wantCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/test/68f9f4f82464ad9c35d5b699", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+			suite.Equal(tt.wantETag, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func (suite *TaskHandlerTestSuite) TestTaskAuthorization() {
+	tests := []struct {
+		name     string
+		role     string
+		method   string
+		wantCode int
+	}{
+		{
+			name:     "viewer - get allowed",
+			role:     model.RoleViewer,
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "viewer - post forbidden",
+			role:     model.RoleViewer,
+			method:   http.MethodPost,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "viewer - put forbidden",
+			role:     model.RoleViewer,
+			method:   http.MethodPut,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "viewer - patch forbidden",
+			role:     model.RoleViewer,
+			method:   http.MethodPatch,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "viewer - delete forbidden",
+			role:     model.RoleViewer,
+			method:   http.MethodDelete,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "member - delete allowed",
+			role:     model.RoleMember,
+			method:   http.MethodDelete,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "admin - put allowed",
+			role:     model.RoleAdmin,
+			method:   http.MethodPut,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "unknown role - get forbidden",
+			role:     "guest",
+			method:   http.MethodGet,
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			app := gin.New()
+			app.Use(ErrorHandler())
+			app.Handle(tt.method, "/test", MockTokenWithRole(tt.role), TaskAuthorization(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/test", nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *TaskHandlerTestSuite) TestViewerReadsTasks() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test/:id", MockTokenWithRole(model.RoleViewer), TaskAuthorization(), suite.Module.getTask)
+
+	id := "68f9f4f82464ad9c35d5b699"
+	viewer := model.Principal{UserID: "test-user", Role: model.RoleViewer}
+	// the use case decides which tasks a viewer reads, the middleware lets the read through
+	suite.TaskUseCaseMock.EXPECT().GetTask(mock.Anything, viewer, id).
+		Return(&model.TaskResponse{ID: id, Version: 1}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/test/"+id, nil)
+	req.Header.Set("Accept", "application/json")
+	app.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+}
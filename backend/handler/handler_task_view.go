@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"net/http"
+)
+
+// views are list settings rather than tasks, so every role may keep its own
+func registerTaskViewHandler(route *gin.Engine) {
+	view := route.Group("/views", AuthMiddleware())
+	read := RequireScope(model.ScopeTasksRead)
+	write := RequireScope(model.ScopeTasksWrite)
+	view.GET("", read, InstanceHandler.listTaskViews)
+	view.GET("/:id", read, InstanceHandler.getTaskView)
+	view.POST("", write, InstanceHandler.createTaskView)
+	view.PUT("/:id", write, InstanceHandler.updateTaskView)
+	view.DELETE("/:id", write, InstanceHandler.deleteTaskView)
+}
+
+func (i MainInstance) listTaskViews(c *gin.Context) {
+	res, err := i.taskViewUseCase.ListViews(c, principal(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": res,
+	})
+}
+
+func (i MainInstance) getTaskView(c *gin.Context) {
+	var param model.TaskViewGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskViewUseCase.GetView(c, principal(c), param.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) createTaskView(c *gin.Context) {
+	var body model.TaskViewBodyParam
+	err := c.ShouldBind(&body)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskViewUseCase.CreateView(c, principal(c), body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) updateTaskView(c *gin.Context) {
+	var param model.TaskViewGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	var body model.TaskViewBodyParam
+	err = c.ShouldBind(&body)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	data, err := i.taskViewUseCase.UpdateView(c, principal(c), param.ID, body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+func (i MainInstance) deleteTaskView(c *gin.Context) {
+	var param model.TaskViewGetParam
+	err := c.ShouldBindUri(&param)
+	if err != nil {
+		c.Error(bindError(c, err))
+		return
+	}
+
+	err = i.taskViewUseCase.DeleteView(c, principal(c), param.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// applyView fills param with the saved view it names. The query parameters
+// the client sent win over the view, even empty ones, so filter= lists
+// without the saved filter.
+func (i MainInstance) applyView(c *gin.Context, param *model.TaskListParam) error {
+	view, err := i.taskViewUseCase.GetView(c, principal(c), param.View)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.GetQuery("limit"); !ok {
+		param.Limit = view.PageSize
+	}
+	if _, ok := c.GetQuery("filter"); !ok {
+		param.Filter = view.Filter
+	}
+	_, sort := c.GetQuery("sort")
+	_, sortBy := c.GetQuery("sort_by")
+	if !sort && !sortBy {
+		param.Sort = view.Sort
+	}
+	return nil
+}
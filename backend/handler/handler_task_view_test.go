@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/handler/interfaces/mocks"
+	"github.com/hendrihmwn/crud-task-backend/helper"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type TaskViewHandlerTestSuite struct {
+	suite.Suite
+	Module              *MainInstance
+	TaskUseCaseMock     *mocks.TaskUseCase
+	TaskViewUseCaseMock *mocks.TaskViewUseCase
+}
+
+func (suite *TaskViewHandlerTestSuite) SetupTest() {
+	suite.TaskUseCaseMock = mocks.NewTaskUseCase(suite.T())
+	suite.TaskViewUseCaseMock = mocks.NewTaskViewUseCase(suite.T())
+	suite.Module = &MainInstance{
+		config:          helper.Config{},
+		taskUseCase:     suite.TaskUseCaseMock,
+		taskViewUseCase: suite.TaskViewUseCaseMock,
+	}
+}
+
+func TestTaskViewHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskViewHandlerTestSuite))
+}
+
+func (suite *TaskViewHandlerTestSuite) TestCreateTaskViewHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.POST("/test", MockToken(), suite.Module.createTaskView)
+
+	tests := []struct {
+		name     string
+		body     string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - bad request",
+			body:     `{"name": "  ", "page_size": 500, "sort": "owner_id"}`,
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - invalid filter",
+			body: `{"name": "open", "filter": "status:done", "page_size": 20}`,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().CreateView(mock.Anything, testPrincipal, model.TaskViewBodyParam{Name: "open", Filter: "status:done", PageSize: 20}).
+					Return(nil, usecase.ErrInvalidFilter).Once()
+			},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "error - name taken",
+			body: `{"name": "open", "page_size": 20}`,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().CreateView(mock.Anything, testPrincipal, model.TaskViewBodyParam{Name: "open", PageSize: 20}).
+					Return(nil, usecase.ErrViewNameTaken).Once()
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "success",
+			body: `{"name": "urgent first", "filter": "status!=completed", "sort": "-priority,created_at", "page_size": 20, "shared": true}`,
+			mock: func() {
+				body := model.TaskViewBodyParam{Name: "urgent first", Filter: "status!=completed", Sort: "-priority,created_at", PageSize: 20, Shared: true}
+				suite.TaskViewUseCaseMock.EXPECT().CreateView(mock.Anything, testPrincipal, body).
+					Return(&model.TaskView{ID: primitive.NewObjectID(), OwnerID: testPrincipal.UserID, Name: body.Name}, nil).Once()
+			},
+			wantCode: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *TaskViewHandlerTestSuite) TestUpdateTaskViewHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.PUT("/test/:id", MockToken(), suite.Module.updateTaskView)
+
+	id := "68fc6a818c54acf4a737d7ab"
+	body := model.TaskViewBodyParam{Name: "open", PageSize: 10}
+	tests := []struct {
+		name     string
+		id       string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:     "error - malformed id",
+			id:       "xxx",
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error - shared view of someone else",
+			id:   id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().UpdateView(mock.Anything, testPrincipal, id, body).
+					Return(nil, usecase.ErrViewNotOwner).Once()
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "success",
+			id:   id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().UpdateView(mock.Anything, testPrincipal, id, body).
+					Return(&model.TaskView{Name: "open", PageSize: 10}, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			data, _ := json.Marshal(body)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/test/"+tt.id, bytes.NewBuffer(data))
+			req.Header.Set("Content-Type", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *TaskViewHandlerTestSuite) TestDeleteTaskViewHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.DELETE("/test/:id", MockToken(), suite.Module.deleteTaskView)
+
+	id := "68fc6a818c54acf4a737d7ab"
+	suite.TaskViewUseCaseMock.EXPECT().DeleteView(mock.Anything, testPrincipal, id).
+		Return(usecase.ErrNotFound).Once()
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest("DELETE", "/test/"+id, nil))
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *TaskViewHandlerTestSuite) TestListTaskWithViewHandler() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test", MockToken(), suite.Module.listTask)
+
+	id := "68fc6a818c54acf4a737d7ab"
+	view := &model.TaskView{Name: "urgent first", Filter: "status!=completed", Sort: "-priority,created_at", PageSize: 20}
+
+	tests := []struct {
+		name     string
+		query    string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:  "error - view not found",
+			query: "page=1&view=" + id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().GetView(mock.Anything, testPrincipal, id).
+					Return(nil, usecase.ErrNotFound).Once()
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "error - malformed view is not looked up",
+			query:    "page=1&view=xxx",
+			mock:     func() {},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:  "error - page mode with limit 0",
+			query: "page=1&limit=0&view=" + id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().GetView(mock.Anything, testPrincipal, id).Return(view, nil).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:  "error - cursor mode with an empty limit",
+			query: "limit=&view=" + id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().GetView(mock.Anything, testPrincipal, id).Return(view, nil).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:  "error - cursor mode with limit 0",
+			query: "limit=0&view=" + id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().GetView(mock.Anything, testPrincipal, id).Return(view, nil).Once()
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:  "success - the view fills the list params",
+			query: "page=1&status=todo&view=" + id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().GetView(mock.Anything, testPrincipal, id).Return(view, nil).Once()
+				suite.TaskUseCaseMock.EXPECT().ListTask(mock.Anything, testPrincipal, model.TaskListParam{
+					Page: 1, Limit: 20, Status: model.TaskStatusTodo, View: id, Filter: "status!=completed", Sort: "-priority,created_at",
+				}).Return(nil, 0, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:  "success - query params override the view",
+			query: "page=1&limit=5&filter=&sort_by=title&view=" + id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().GetView(mock.Anything, testPrincipal, id).Return(view, nil).Once()
+				suite.TaskUseCaseMock.EXPECT().ListTask(mock.Anything, testPrincipal, model.TaskListParam{
+					Page: 1, Limit: 5, View: id, SortBy: "title",
+				}).Return(nil, 0, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:  "success - cursor pages use the view too",
+			query: "view=" + id,
+			mock: func() {
+				suite.TaskViewUseCaseMock.EXPECT().GetView(mock.Anything, testPrincipal, id).Return(view, nil).Once()
+				suite.TaskUseCaseMock.EXPECT().ListTaskCursor(mock.Anything, testPrincipal, model.TaskListParam{
+					Limit: 20, View: id, Filter: "status!=completed", Sort: "-priority,created_at",
+				}).Return(nil, "", nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test?"+tt.query, nil)
+			req.Header.Set("Accept", "application/json")
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code, w.Body.String())
+		})
+	}
+}
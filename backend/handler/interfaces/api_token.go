@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+)
+
+//go:generate mockery --name=APITokenUseCase --keeptree --output=mocks --case=underscore --with-expecter=true
+type APITokenUseCase interface {
+	CreateToken(ctx context.Context, principal model.Principal, body model.APITokenBodyParam) (res *model.APITokenResponse, err error)
+	ListTokens(ctx context.Context, principal model.Principal) (res []model.APITokenResponse, err error)
+	RevokeToken(ctx context.Context, principal model.Principal, id string) (err error)
+	Authenticate(ctx context.Context, token string) (res model.Principal, err error)
+}
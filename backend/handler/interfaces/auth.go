@@ -8,4 +8,7 @@ import (
 //go:generate mockery --name=AuthUseCase --keeptree --output=mocks --case=underscore --with-expecter=true
 type AuthUseCase interface {
 	Login(ctx context.Context, param model.LoginParam) (res model.AuthResponse, err error)
+	Register(ctx context.Context, param model.RegisterParam) (res model.UserResponse, err error)
+	Refresh(ctx context.Context, param model.RefreshTokenParam) (res model.AuthResponse, err error)
+	Logout(ctx context.Context, param model.RefreshTokenParam) (err error)
 }
@@ -0,0 +1,262 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+)
+
+// APITokenUseCase is an autogenerated mock type for the APITokenUseCase type
+type APITokenUseCase struct {
+	mock.Mock
+}
+
+type APITokenUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APITokenUseCase) EXPECT() *APITokenUseCase_Expecter {
+	return &APITokenUseCase_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *APITokenUseCase) Authenticate(ctx context.Context, token string) (model.Principal, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 model.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.Principal, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Principal); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(model.Principal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APITokenUseCase_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type APITokenUseCase_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *APITokenUseCase_Expecter) Authenticate(ctx interface{}, token interface{}) *APITokenUseCase_Authenticate_Call {
+	return &APITokenUseCase_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, token)}
+}
+
+func (_c *APITokenUseCase_Authenticate_Call) Run(run func(ctx context.Context, token string)) *APITokenUseCase_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APITokenUseCase_Authenticate_Call) Return(res model.Principal, err error) *APITokenUseCase_Authenticate_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *APITokenUseCase_Authenticate_Call) RunAndReturn(run func(context.Context, string) (model.Principal, error)) *APITokenUseCase_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateToken provides a mock function with given fields: ctx, principal, body
+func (_m *APITokenUseCase) CreateToken(ctx context.Context, principal model.Principal, body model.APITokenBodyParam) (*model.APITokenResponse, error) {
+	ret := _m.Called(ctx, principal, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 *model.APITokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.APITokenBodyParam) (*model.APITokenResponse, error)); ok {
+		return rf(ctx, principal, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.APITokenBodyParam) *model.APITokenResponse); ok {
+		r0 = rf(ctx, principal, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APITokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, model.APITokenBodyParam) error); ok {
+		r1 = rf(ctx, principal, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APITokenUseCase_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type APITokenUseCase_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - body model.APITokenBodyParam
+func (_e *APITokenUseCase_Expecter) CreateToken(ctx interface{}, principal interface{}, body interface{}) *APITokenUseCase_CreateToken_Call {
+	return &APITokenUseCase_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, principal, body)}
+}
+
+func (_c *APITokenUseCase_CreateToken_Call) Run(run func(ctx context.Context, principal model.Principal, body model.APITokenBodyParam)) *APITokenUseCase_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(model.APITokenBodyParam))
+	})
+	return _c
+}
+
+func (_c *APITokenUseCase_CreateToken_Call) Return(res *model.APITokenResponse, err error) *APITokenUseCase_CreateToken_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *APITokenUseCase_CreateToken_Call) RunAndReturn(run func(context.Context, model.Principal, model.APITokenBodyParam) (*model.APITokenResponse, error)) *APITokenUseCase_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTokens provides a mock function with given fields: ctx, principal
+func (_m *APITokenUseCase) ListTokens(ctx context.Context, principal model.Principal) ([]model.APITokenResponse, error) {
+	ret := _m.Called(ctx, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTokens")
+	}
+
+	var r0 []model.APITokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal) ([]model.APITokenResponse, error)); ok {
+		return rf(ctx, principal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal) []model.APITokenResponse); ok {
+		r0 = rf(ctx, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.APITokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal) error); ok {
+		r1 = rf(ctx, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APITokenUseCase_ListTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTokens'
+type APITokenUseCase_ListTokens_Call struct {
+	*mock.Call
+}
+
+// ListTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+func (_e *APITokenUseCase_Expecter) ListTokens(ctx interface{}, principal interface{}) *APITokenUseCase_ListTokens_Call {
+	return &APITokenUseCase_ListTokens_Call{Call: _e.mock.On("ListTokens", ctx, principal)}
+}
+
+func (_c *APITokenUseCase_ListTokens_Call) Run(run func(ctx context.Context, principal model.Principal)) *APITokenUseCase_ListTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal))
+	})
+	return _c
+}
+
+func (_c *APITokenUseCase_ListTokens_Call) Return(res []model.APITokenResponse, err error) *APITokenUseCase_ListTokens_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *APITokenUseCase_ListTokens_Call) RunAndReturn(run func(context.Context, model.Principal) ([]model.APITokenResponse, error)) *APITokenUseCase_ListTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeToken provides a mock function with given fields: ctx, principal, id
+func (_m *APITokenUseCase) RevokeToken(ctx context.Context, principal model.Principal, id string) error {
+	ret := _m.Called(ctx, principal, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) error); ok {
+		r0 = rf(ctx, principal, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APITokenUseCase_RevokeToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeToken'
+type APITokenUseCase_RevokeToken_Call struct {
+	*mock.Call
+}
+
+// RevokeToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+func (_e *APITokenUseCase_Expecter) RevokeToken(ctx interface{}, principal interface{}, id interface{}) *APITokenUseCase_RevokeToken_Call {
+	return &APITokenUseCase_RevokeToken_Call{Call: _e.mock.On("RevokeToken", ctx, principal, id)}
+}
+
+func (_c *APITokenUseCase_RevokeToken_Call) Run(run func(ctx context.Context, principal model.Principal, id string)) *APITokenUseCase_RevokeToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *APITokenUseCase_RevokeToken_Call) Return(err error) *APITokenUseCase_RevokeToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *APITokenUseCase_RevokeToken_Call) RunAndReturn(run func(context.Context, model.Principal, string) error) *APITokenUseCase_RevokeToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPITokenUseCase creates a new instance of APITokenUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPITokenUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APITokenUseCase {
+	mock := &APITokenUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
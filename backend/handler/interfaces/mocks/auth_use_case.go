@@ -80,6 +80,167 @@ func (_c *AuthUseCase_Login_Call) RunAndReturn(run func(context.Context, model.L
 	return _c
 }
 
+// Logout provides a mock function with given fields: ctx, param
+func (_m *AuthUseCase) Logout(ctx context.Context, param model.RefreshTokenParam) error {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshTokenParam) error); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthUseCase_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type AuthUseCase_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - param model.RefreshTokenParam
+func (_e *AuthUseCase_Expecter) Logout(ctx interface{}, param interface{}) *AuthUseCase_Logout_Call {
+	return &AuthUseCase_Logout_Call{Call: _e.mock.On("Logout", ctx, param)}
+}
+
+func (_c *AuthUseCase_Logout_Call) Run(run func(ctx context.Context, param model.RefreshTokenParam)) *AuthUseCase_Logout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.RefreshTokenParam))
+	})
+	return _c
+}
+
+func (_c *AuthUseCase_Logout_Call) Return(err error) *AuthUseCase_Logout_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *AuthUseCase_Logout_Call) RunAndReturn(run func(context.Context, model.RefreshTokenParam) error) *AuthUseCase_Logout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, param
+func (_m *AuthUseCase) Refresh(ctx context.Context, param model.RefreshTokenParam) (model.AuthResponse, error) {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 model.AuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshTokenParam) (model.AuthResponse, error)); ok {
+		return rf(ctx, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshTokenParam) model.AuthResponse); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(model.AuthResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.RefreshTokenParam) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUseCase_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type AuthUseCase_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - param model.RefreshTokenParam
+func (_e *AuthUseCase_Expecter) Refresh(ctx interface{}, param interface{}) *AuthUseCase_Refresh_Call {
+	return &AuthUseCase_Refresh_Call{Call: _e.mock.On("Refresh", ctx, param)}
+}
+
+func (_c *AuthUseCase_Refresh_Call) Run(run func(ctx context.Context, param model.RefreshTokenParam)) *AuthUseCase_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.RefreshTokenParam))
+	})
+	return _c
+}
+
+func (_c *AuthUseCase_Refresh_Call) Return(res model.AuthResponse, err error) *AuthUseCase_Refresh_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *AuthUseCase_Refresh_Call) RunAndReturn(run func(context.Context, model.RefreshTokenParam) (model.AuthResponse, error)) *AuthUseCase_Refresh_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Register provides a mock function with given fields: ctx, param
+func (_m *AuthUseCase) Register(ctx context.Context, param model.RegisterParam) (model.UserResponse, error) {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 model.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.RegisterParam) (model.UserResponse, error)); ok {
+		return rf(ctx, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.RegisterParam) model.UserResponse); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(model.UserResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.RegisterParam) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthUseCase_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type AuthUseCase_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - ctx context.Context
+//   - param model.RegisterParam
+func (_e *AuthUseCase_Expecter) Register(ctx interface{}, param interface{}) *AuthUseCase_Register_Call {
+	return &AuthUseCase_Register_Call{Call: _e.mock.On("Register", ctx, param)}
+}
+
+func (_c *AuthUseCase_Register_Call) Run(run func(ctx context.Context, param model.RegisterParam)) *AuthUseCase_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.RegisterParam))
+	})
+	return _c
+}
+
+func (_c *AuthUseCase_Register_Call) Return(res model.UserResponse, err error) *AuthUseCase_Register_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *AuthUseCase_Register_Call) RunAndReturn(run func(context.Context, model.RegisterParam) (model.UserResponse, error)) *AuthUseCase_Register_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewAuthUseCase creates a new instance of AuthUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthUseCase(t interface {
@@ -23,9 +23,133 @@ func (_m *TaskUseCase) EXPECT() *TaskUseCase_Expecter {
 	return &TaskUseCase_Expecter{mock: &_m.Mock}
 }
 
-// CreateTask provides a mock function with given fields: ctx, body
-func (_m *TaskUseCase) CreateTask(ctx context.Context, body model.TaskBodyParam) (*model.TaskResponse, error) {
-	ret := _m.Called(ctx, body)
+// AddChecklistItem provides a mock function with given fields: ctx, principal, id, version, body
+func (_m *TaskUseCase) AddChecklistItem(ctx context.Context, principal model.Principal, id string, version int64, body model.ChecklistItemBodyParam) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, version, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddChecklistItem")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, model.ChecklistItemBodyParam) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, version, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, model.ChecklistItemBodyParam) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, version, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, int64, model.ChecklistItemBodyParam) error); ok {
+		r1 = rf(ctx, principal, id, version, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_AddChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddChecklistItem'
+type TaskUseCase_AddChecklistItem_Call struct {
+	*mock.Call
+}
+
+// AddChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - version int64
+//   - body model.ChecklistItemBodyParam
+func (_e *TaskUseCase_Expecter) AddChecklistItem(ctx interface{}, principal interface{}, id interface{}, version interface{}, body interface{}) *TaskUseCase_AddChecklistItem_Call {
+	return &TaskUseCase_AddChecklistItem_Call{Call: _e.mock.On("AddChecklistItem", ctx, principal, id, version, body)}
+}
+
+func (_c *TaskUseCase_AddChecklistItem_Call) Run(run func(ctx context.Context, principal model.Principal, id string, version int64, body model.ChecklistItemBodyParam)) *TaskUseCase_AddChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(int64), args[4].(model.ChecklistItemBodyParam))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_AddChecklistItem_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_AddChecklistItem_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_AddChecklistItem_Call) RunAndReturn(run func(context.Context, model.Principal, string, int64, model.ChecklistItemBodyParam) (*model.TaskResponse, error)) *TaskUseCase_AddChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddDependency provides a mock function with given fields: ctx, principal, id, version, blockerID
+func (_m *TaskUseCase) AddDependency(ctx context.Context, principal model.Principal, id string, version int64, blockerID string) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, version, blockerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDependency")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, string) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, version, blockerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, string) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, version, blockerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, int64, string) error); ok {
+		r1 = rf(ctx, principal, id, version, blockerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_AddDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDependency'
+type TaskUseCase_AddDependency_Call struct {
+	*mock.Call
+}
+
+// AddDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - version int64
+//   - blockerID string
+func (_e *TaskUseCase_Expecter) AddDependency(ctx interface{}, principal interface{}, id interface{}, version interface{}, blockerID interface{}) *TaskUseCase_AddDependency_Call {
+	return &TaskUseCase_AddDependency_Call{Call: _e.mock.On("AddDependency", ctx, principal, id, version, blockerID)}
+}
+
+func (_c *TaskUseCase_AddDependency_Call) Run(run func(ctx context.Context, principal model.Principal, id string, version int64, blockerID string)) *TaskUseCase_AddDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(int64), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_AddDependency_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_AddDependency_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_AddDependency_Call) RunAndReturn(run func(context.Context, model.Principal, string, int64, string) (*model.TaskResponse, error)) *TaskUseCase_AddDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTask provides a mock function with given fields: ctx, principal, body
+func (_m *TaskUseCase) CreateTask(ctx context.Context, principal model.Principal, body model.TaskBodyParam) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, body)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTask")
@@ -33,19 +157,19 @@ func (_m *TaskUseCase) CreateTask(ctx context.Context, body model.TaskBodyParam)
 
 	var r0 *model.TaskResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, model.TaskBodyParam) (*model.TaskResponse, error)); ok {
-		return rf(ctx, body)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskBodyParam) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, body)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.TaskBodyParam) *model.TaskResponse); ok {
-		r0 = rf(ctx, body)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskBodyParam) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, body)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.TaskResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, model.TaskBodyParam) error); ok {
-		r1 = rf(ctx, body)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, model.TaskBodyParam) error); ok {
+		r1 = rf(ctx, principal, body)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,14 +184,15 @@ type TaskUseCase_CreateTask_Call struct {
 
 // CreateTask is a helper method to define mock.On call
 //   - ctx context.Context
+//   - principal model.Principal
 //   - body model.TaskBodyParam
-func (_e *TaskUseCase_Expecter) CreateTask(ctx interface{}, body interface{}) *TaskUseCase_CreateTask_Call {
-	return &TaskUseCase_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, body)}
+func (_e *TaskUseCase_Expecter) CreateTask(ctx interface{}, principal interface{}, body interface{}) *TaskUseCase_CreateTask_Call {
+	return &TaskUseCase_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, principal, body)}
 }
 
-func (_c *TaskUseCase_CreateTask_Call) Run(run func(ctx context.Context, body model.TaskBodyParam)) *TaskUseCase_CreateTask_Call {
+func (_c *TaskUseCase_CreateTask_Call) Run(run func(ctx context.Context, principal model.Principal, body model.TaskBodyParam)) *TaskUseCase_CreateTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(model.TaskBodyParam))
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(model.TaskBodyParam))
 	})
 	return _c
 }
@@ -77,22 +202,22 @@ func (_c *TaskUseCase_CreateTask_Call) Return(res *model.TaskResponse, err error
 	return _c
 }
 
-func (_c *TaskUseCase_CreateTask_Call) RunAndReturn(run func(context.Context, model.TaskBodyParam) (*model.TaskResponse, error)) *TaskUseCase_CreateTask_Call {
+func (_c *TaskUseCase_CreateTask_Call) RunAndReturn(run func(context.Context, model.Principal, model.TaskBodyParam) (*model.TaskResponse, error)) *TaskUseCase_CreateTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTask provides a mock function with given fields: ctx, id
-func (_m *TaskUseCase) DeleteTask(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// DeleteTask provides a mock function with given fields: ctx, principal, id, version
+func (_m *TaskUseCase) DeleteTask(ctx context.Context, principal model.Principal, id string, version int64) error {
+	ret := _m.Called(ctx, principal, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64) error); ok {
+		r0 = rf(ctx, principal, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -107,14 +232,16 @@ type TaskUseCase_DeleteTask_Call struct {
 
 // DeleteTask is a helper method to define mock.On call
 //   - ctx context.Context
+//   - principal model.Principal
 //   - id string
-func (_e *TaskUseCase_Expecter) DeleteTask(ctx interface{}, id interface{}) *TaskUseCase_DeleteTask_Call {
-	return &TaskUseCase_DeleteTask_Call{Call: _e.mock.On("DeleteTask", ctx, id)}
+//   - version int64
+func (_e *TaskUseCase_Expecter) DeleteTask(ctx interface{}, principal interface{}, id interface{}, version interface{}) *TaskUseCase_DeleteTask_Call {
+	return &TaskUseCase_DeleteTask_Call{Call: _e.mock.On("DeleteTask", ctx, principal, id, version)}
 }
 
-func (_c *TaskUseCase_DeleteTask_Call) Run(run func(ctx context.Context, id string)) *TaskUseCase_DeleteTask_Call {
+func (_c *TaskUseCase_DeleteTask_Call) Run(run func(ctx context.Context, principal model.Principal, id string, version int64)) *TaskUseCase_DeleteTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(int64))
 	})
 	return _c
 }
@@ -124,14 +251,14 @@ func (_c *TaskUseCase_DeleteTask_Call) Return(err error) *TaskUseCase_DeleteTask
 	return _c
 }
 
-func (_c *TaskUseCase_DeleteTask_Call) RunAndReturn(run func(context.Context, string) error) *TaskUseCase_DeleteTask_Call {
+func (_c *TaskUseCase_DeleteTask_Call) RunAndReturn(run func(context.Context, model.Principal, string, int64) error) *TaskUseCase_DeleteTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTask provides a mock function with given fields: ctx, id
-func (_m *TaskUseCase) GetTask(ctx context.Context, id string) (*model.TaskResponse, error) {
-	ret := _m.Called(ctx, id)
+// GetTask provides a mock function with given fields: ctx, principal, id
+func (_m *TaskUseCase) GetTask(ctx context.Context, principal model.Principal, id string) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTask")
@@ -139,19 +266,19 @@ func (_m *TaskUseCase) GetTask(ctx context.Context, id string) (*model.TaskRespo
 
 	var r0 *model.TaskResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.TaskResponse, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.TaskResponse); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.TaskResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string) error); ok {
+		r1 = rf(ctx, principal, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -166,14 +293,15 @@ type TaskUseCase_GetTask_Call struct {
 
 // GetTask is a helper method to define mock.On call
 //   - ctx context.Context
+//   - principal model.Principal
 //   - id string
-func (_e *TaskUseCase_Expecter) GetTask(ctx interface{}, id interface{}) *TaskUseCase_GetTask_Call {
-	return &TaskUseCase_GetTask_Call{Call: _e.mock.On("GetTask", ctx, id)}
+func (_e *TaskUseCase_Expecter) GetTask(ctx interface{}, principal interface{}, id interface{}) *TaskUseCase_GetTask_Call {
+	return &TaskUseCase_GetTask_Call{Call: _e.mock.On("GetTask", ctx, principal, id)}
 }
 
-func (_c *TaskUseCase_GetTask_Call) Run(run func(ctx context.Context, id string)) *TaskUseCase_GetTask_Call {
+func (_c *TaskUseCase_GetTask_Call) Run(run func(ctx context.Context, principal model.Principal, id string)) *TaskUseCase_GetTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string))
 	})
 	return _c
 }
@@ -183,14 +311,73 @@ func (_c *TaskUseCase_GetTask_Call) Return(res *model.TaskResponse, err error) *
 	return _c
 }
 
-func (_c *TaskUseCase_GetTask_Call) RunAndReturn(run func(context.Context, string) (*model.TaskResponse, error)) *TaskUseCase_GetTask_Call {
+func (_c *TaskUseCase_GetTask_Call) RunAndReturn(run func(context.Context, model.Principal, string) (*model.TaskResponse, error)) *TaskUseCase_GetTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: ctx, principal
+func (_m *TaskUseCase) ListTags(ctx context.Context, principal model.Principal) ([]model.TagCount, error) {
+	ret := _m.Called(ctx, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []model.TagCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal) ([]model.TagCount, error)); ok {
+		return rf(ctx, principal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal) []model.TagCount); ok {
+		r0 = rf(ctx, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TagCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal) error); ok {
+		r1 = rf(ctx, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type TaskUseCase_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+func (_e *TaskUseCase_Expecter) ListTags(ctx interface{}, principal interface{}) *TaskUseCase_ListTags_Call {
+	return &TaskUseCase_ListTags_Call{Call: _e.mock.On("ListTags", ctx, principal)}
+}
+
+func (_c *TaskUseCase_ListTags_Call) Run(run func(ctx context.Context, principal model.Principal)) *TaskUseCase_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_ListTags_Call) Return(res []model.TagCount, err error) *TaskUseCase_ListTags_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_ListTags_Call) RunAndReturn(run func(context.Context, model.Principal) ([]model.TagCount, error)) *TaskUseCase_ListTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListTask provides a mock function with given fields: ctx, param
-func (_m *TaskUseCase) ListTask(ctx context.Context, param model.TaskListParam) ([]model.TaskResponse, int, error) {
-	ret := _m.Called(ctx, param)
+// ListTask provides a mock function with given fields: ctx, principal, param
+func (_m *TaskUseCase) ListTask(ctx context.Context, principal model.Principal, param model.TaskListParam) ([]model.TaskResponse, int, error) {
+	ret := _m.Called(ctx, principal, param)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListTask")
@@ -199,25 +386,25 @@ func (_m *TaskUseCase) ListTask(ctx context.Context, param model.TaskListParam)
 	var r0 []model.TaskResponse
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, model.TaskListParam) ([]model.TaskResponse, int, error)); ok {
-		return rf(ctx, param)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskListParam) ([]model.TaskResponse, int, error)); ok {
+		return rf(ctx, principal, param)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.TaskListParam) []model.TaskResponse); ok {
-		r0 = rf(ctx, param)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskListParam) []model.TaskResponse); ok {
+		r0 = rf(ctx, principal, param)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.TaskResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, model.TaskListParam) int); ok {
-		r1 = rf(ctx, param)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, model.TaskListParam) int); ok {
+		r1 = rf(ctx, principal, param)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, model.TaskListParam) error); ok {
-		r2 = rf(ctx, param)
+	if rf, ok := ret.Get(2).(func(context.Context, model.Principal, model.TaskListParam) error); ok {
+		r2 = rf(ctx, principal, param)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -232,14 +419,15 @@ type TaskUseCase_ListTask_Call struct {
 
 // ListTask is a helper method to define mock.On call
 //   - ctx context.Context
+//   - principal model.Principal
 //   - param model.TaskListParam
-func (_e *TaskUseCase_Expecter) ListTask(ctx interface{}, param interface{}) *TaskUseCase_ListTask_Call {
-	return &TaskUseCase_ListTask_Call{Call: _e.mock.On("ListTask", ctx, param)}
+func (_e *TaskUseCase_Expecter) ListTask(ctx interface{}, principal interface{}, param interface{}) *TaskUseCase_ListTask_Call {
+	return &TaskUseCase_ListTask_Call{Call: _e.mock.On("ListTask", ctx, principal, param)}
 }
 
-func (_c *TaskUseCase_ListTask_Call) Run(run func(ctx context.Context, param model.TaskListParam)) *TaskUseCase_ListTask_Call {
+func (_c *TaskUseCase_ListTask_Call) Run(run func(ctx context.Context, principal model.Principal, param model.TaskListParam)) *TaskUseCase_ListTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(model.TaskListParam))
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(model.TaskListParam))
 	})
 	return _c
 }
@@ -249,14 +437,648 @@ func (_c *TaskUseCase_ListTask_Call) Return(res []model.TaskResponse, size int,
 	return _c
 }
 
-func (_c *TaskUseCase_ListTask_Call) RunAndReturn(run func(context.Context, model.TaskListParam) ([]model.TaskResponse, int, error)) *TaskUseCase_ListTask_Call {
+func (_c *TaskUseCase_ListTask_Call) RunAndReturn(run func(context.Context, model.Principal, model.TaskListParam) ([]model.TaskResponse, int, error)) *TaskUseCase_ListTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTaskCursor provides a mock function with given fields: ctx, principal, param
+func (_m *TaskUseCase) ListTaskCursor(ctx context.Context, principal model.Principal, param model.TaskListParam) ([]model.TaskResponse, string, error) {
+	ret := _m.Called(ctx, principal, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTaskCursor")
+	}
+
+	var r0 []model.TaskResponse
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskListParam) ([]model.TaskResponse, string, error)); ok {
+		return rf(ctx, principal, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskListParam) []model.TaskResponse); ok {
+		r0 = rf(ctx, principal, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, model.TaskListParam) string); ok {
+		r1 = rf(ctx, principal, param)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.Principal, model.TaskListParam) error); ok {
+		r2 = rf(ctx, principal, param)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TaskUseCase_ListTaskCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTaskCursor'
+type TaskUseCase_ListTaskCursor_Call struct {
+	*mock.Call
+}
+
+// ListTaskCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - param model.TaskListParam
+func (_e *TaskUseCase_Expecter) ListTaskCursor(ctx interface{}, principal interface{}, param interface{}) *TaskUseCase_ListTaskCursor_Call {
+	return &TaskUseCase_ListTaskCursor_Call{Call: _e.mock.On("ListTaskCursor", ctx, principal, param)}
+}
+
+func (_c *TaskUseCase_ListTaskCursor_Call) Run(run func(ctx context.Context, principal model.Principal, param model.TaskListParam)) *TaskUseCase_ListTaskCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(model.TaskListParam))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_ListTaskCursor_Call) Return(res []model.TaskResponse, next string, err error) *TaskUseCase_ListTaskCursor_Call {
+	_c.Call.Return(res, next, err)
+	return _c
+}
+
+func (_c *TaskUseCase_ListTaskCursor_Call) RunAndReturn(run func(context.Context, model.Principal, model.TaskListParam) ([]model.TaskResponse, string, error)) *TaskUseCase_ListTaskCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTrash provides a mock function with given fields: ctx, principal, param
+func (_m *TaskUseCase) ListTrash(ctx context.Context, principal model.Principal, param model.TaskTrashParam) ([]model.TaskResponse, int, error) {
+	ret := _m.Called(ctx, principal, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTrash")
+	}
+
+	var r0 []model.TaskResponse
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskTrashParam) ([]model.TaskResponse, int, error)); ok {
+		return rf(ctx, principal, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskTrashParam) []model.TaskResponse); ok {
+		r0 = rf(ctx, principal, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, model.TaskTrashParam) int); ok {
+		r1 = rf(ctx, principal, param)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.Principal, model.TaskTrashParam) error); ok {
+		r2 = rf(ctx, principal, param)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TaskUseCase_ListTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrash'
+type TaskUseCase_ListTrash_Call struct {
+	*mock.Call
+}
+
+// ListTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - param model.TaskTrashParam
+func (_e *TaskUseCase_Expecter) ListTrash(ctx interface{}, principal interface{}, param interface{}) *TaskUseCase_ListTrash_Call {
+	return &TaskUseCase_ListTrash_Call{Call: _e.mock.On("ListTrash", ctx, principal, param)}
+}
+
+func (_c *TaskUseCase_ListTrash_Call) Run(run func(ctx context.Context, principal model.Principal, param model.TaskTrashParam)) *TaskUseCase_ListTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(model.TaskTrashParam))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_ListTrash_Call) Return(res []model.TaskResponse, size int, err error) *TaskUseCase_ListTrash_Call {
+	_c.Call.Return(res, size, err)
+	return _c
+}
+
+func (_c *TaskUseCase_ListTrash_Call) RunAndReturn(run func(context.Context, model.Principal, model.TaskTrashParam) ([]model.TaskResponse, int, error)) *TaskUseCase_ListTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchTask provides a mock function with given fields: ctx, principal, id, version, patch
+func (_m *TaskUseCase) PatchTask(ctx context.Context, principal model.Principal, id string, version int64, patch model.TaskPatchParam) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTask")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, model.TaskPatchParam) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, model.TaskPatchParam) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, int64, model.TaskPatchParam) error); ok {
+		r1 = rf(ctx, principal, id, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_PatchTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchTask'
+type TaskUseCase_PatchTask_Call struct {
+	*mock.Call
+}
+
+// PatchTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - version int64
+//   - patch model.TaskPatchParam
+func (_e *TaskUseCase_Expecter) PatchTask(ctx interface{}, principal interface{}, id interface{}, version interface{}, patch interface{}) *TaskUseCase_PatchTask_Call {
+	return &TaskUseCase_PatchTask_Call{Call: _e.mock.On("PatchTask", ctx, principal, id, version, patch)}
+}
+
+func (_c *TaskUseCase_PatchTask_Call) Run(run func(ctx context.Context, principal model.Principal, id string, version int64, patch model.TaskPatchParam)) *TaskUseCase_PatchTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(int64), args[4].(model.TaskPatchParam))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_PatchTask_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_PatchTask_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_PatchTask_Call) RunAndReturn(run func(context.Context, model.Principal, string, int64, model.TaskPatchParam) (*model.TaskResponse, error)) *TaskUseCase_PatchTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveChecklistItem provides a mock function with given fields: ctx, principal, id, itemID, version
+func (_m *TaskUseCase) RemoveChecklistItem(ctx context.Context, principal model.Principal, id string, itemID string, version int64) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, itemID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveChecklistItem")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string, int64) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, itemID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string, int64) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, itemID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, string, int64) error); ok {
+		r1 = rf(ctx, principal, id, itemID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_RemoveChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveChecklistItem'
+type TaskUseCase_RemoveChecklistItem_Call struct {
+	*mock.Call
+}
+
+// RemoveChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - itemID string
+//   - version int64
+func (_e *TaskUseCase_Expecter) RemoveChecklistItem(ctx interface{}, principal interface{}, id interface{}, itemID interface{}, version interface{}) *TaskUseCase_RemoveChecklistItem_Call {
+	return &TaskUseCase_RemoveChecklistItem_Call{Call: _e.mock.On("RemoveChecklistItem", ctx, principal, id, itemID, version)}
+}
+
+func (_c *TaskUseCase_RemoveChecklistItem_Call) Run(run func(ctx context.Context, principal model.Principal, id string, itemID string, version int64)) *TaskUseCase_RemoveChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(string), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_RemoveChecklistItem_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_RemoveChecklistItem_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_RemoveChecklistItem_Call) RunAndReturn(run func(context.Context, model.Principal, string, string, int64) (*model.TaskResponse, error)) *TaskUseCase_RemoveChecklistItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDependency provides a mock function with given fields: ctx, principal, id, blockerID, version
+func (_m *TaskUseCase) RemoveDependency(ctx context.Context, principal model.Principal, id string, blockerID string, version int64) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, blockerID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDependency")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string, int64) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, blockerID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string, int64) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, blockerID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, string, int64) error); ok {
+		r1 = rf(ctx, principal, id, blockerID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_RemoveDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDependency'
+type TaskUseCase_RemoveDependency_Call struct {
+	*mock.Call
+}
+
+// RemoveDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - blockerID string
+//   - version int64
+func (_e *TaskUseCase_Expecter) RemoveDependency(ctx interface{}, principal interface{}, id interface{}, blockerID interface{}, version interface{}) *TaskUseCase_RemoveDependency_Call {
+	return &TaskUseCase_RemoveDependency_Call{Call: _e.mock.On("RemoveDependency", ctx, principal, id, blockerID, version)}
+}
+
+func (_c *TaskUseCase_RemoveDependency_Call) Run(run func(ctx context.Context, principal model.Principal, id string, blockerID string, version int64)) *TaskUseCase_RemoveDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(string), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_RemoveDependency_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_RemoveDependency_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_RemoveDependency_Call) RunAndReturn(run func(context.Context, model.Principal, string, string, int64) (*model.TaskResponse, error)) *TaskUseCase_RemoveDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameTag provides a mock function with given fields: ctx, principal, from, to
+func (_m *TaskUseCase) RenameTag(ctx context.Context, principal model.Principal, from string, to string) (*model.TagRenameResponse, error) {
+	ret := _m.Called(ctx, principal, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameTag")
+	}
+
+	var r0 *model.TagRenameResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string) (*model.TagRenameResponse, error)); ok {
+		return rf(ctx, principal, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string) *model.TagRenameResponse); ok {
+		r0 = rf(ctx, principal, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TagRenameResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, string) error); ok {
+		r1 = rf(ctx, principal, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_RenameTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameTag'
+type TaskUseCase_RenameTag_Call struct {
+	*mock.Call
+}
+
+// RenameTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - from string
+//   - to string
+func (_e *TaskUseCase_Expecter) RenameTag(ctx interface{}, principal interface{}, from interface{}, to interface{}) *TaskUseCase_RenameTag_Call {
+	return &TaskUseCase_RenameTag_Call{Call: _e.mock.On("RenameTag", ctx, principal, from, to)}
+}
+
+func (_c *TaskUseCase_RenameTag_Call) Run(run func(ctx context.Context, principal model.Principal, from string, to string)) *TaskUseCase_RenameTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_RenameTag_Call) Return(res *model.TagRenameResponse, err error) *TaskUseCase_RenameTag_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_RenameTag_Call) RunAndReturn(run func(context.Context, model.Principal, string, string) (*model.TagRenameResponse, error)) *TaskUseCase_RenameTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReorderChecklist provides a mock function with given fields: ctx, principal, id, version, ids
+func (_m *TaskUseCase) ReorderChecklist(ctx context.Context, principal model.Principal, id string, version int64, ids []string) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, version, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderChecklist")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, []string) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, version, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, []string) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, version, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, int64, []string) error); ok {
+		r1 = rf(ctx, principal, id, version, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_ReorderChecklist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderChecklist'
+type TaskUseCase_ReorderChecklist_Call struct {
+	*mock.Call
+}
+
+// ReorderChecklist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - version int64
+//   - ids []string
+func (_e *TaskUseCase_Expecter) ReorderChecklist(ctx interface{}, principal interface{}, id interface{}, version interface{}, ids interface{}) *TaskUseCase_ReorderChecklist_Call {
+	return &TaskUseCase_ReorderChecklist_Call{Call: _e.mock.On("ReorderChecklist", ctx, principal, id, version, ids)}
+}
+
+func (_c *TaskUseCase_ReorderChecklist_Call) Run(run func(ctx context.Context, principal model.Principal, id string, version int64, ids []string)) *TaskUseCase_ReorderChecklist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(int64), args[4].([]string))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_ReorderChecklist_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_ReorderChecklist_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_ReorderChecklist_Call) RunAndReturn(run func(context.Context, model.Principal, string, int64, []string) (*model.TaskResponse, error)) *TaskUseCase_ReorderChecklist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTask provides a mock function with given fields: ctx, principal, id
+func (_m *TaskUseCase) RestoreTask(ctx context.Context, principal model.Principal, id string) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string) error); ok {
+		r1 = rf(ctx, principal, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_RestoreTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTask'
+type TaskUseCase_RestoreTask_Call struct {
+	*mock.Call
+}
+
+// RestoreTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+func (_e *TaskUseCase_Expecter) RestoreTask(ctx interface{}, principal interface{}, id interface{}) *TaskUseCase_RestoreTask_Call {
+	return &TaskUseCase_RestoreTask_Call{Call: _e.mock.On("RestoreTask", ctx, principal, id)}
+}
+
+func (_c *TaskUseCase_RestoreTask_Call) Run(run func(ctx context.Context, principal model.Principal, id string)) *TaskUseCase_RestoreTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_RestoreTask_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_RestoreTask_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_RestoreTask_Call) RunAndReturn(run func(context.Context, model.Principal, string) (*model.TaskResponse, error)) *TaskUseCase_RestoreTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TaskHistory provides a mock function with given fields: ctx, principal, id, param
+func (_m *TaskUseCase) TaskHistory(ctx context.Context, principal model.Principal, id string, param model.TaskHistoryParam) ([]model.TaskEvent, int, error) {
+	ret := _m.Called(ctx, principal, id, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TaskHistory")
+	}
+
+	var r0 []model.TaskEvent
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, model.TaskHistoryParam) ([]model.TaskEvent, int, error)); ok {
+		return rf(ctx, principal, id, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, model.TaskHistoryParam) []model.TaskEvent); ok {
+		r0 = rf(ctx, principal, id, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TaskEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, model.TaskHistoryParam) int); ok {
+		r1 = rf(ctx, principal, id, param)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.Principal, string, model.TaskHistoryParam) error); ok {
+		r2 = rf(ctx, principal, id, param)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TaskUseCase_TaskHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskHistory'
+type TaskUseCase_TaskHistory_Call struct {
+	*mock.Call
+}
+
+// TaskHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - param model.TaskHistoryParam
+func (_e *TaskUseCase_Expecter) TaskHistory(ctx interface{}, principal interface{}, id interface{}, param interface{}) *TaskUseCase_TaskHistory_Call {
+	return &TaskUseCase_TaskHistory_Call{Call: _e.mock.On("TaskHistory", ctx, principal, id, param)}
+}
+
+func (_c *TaskUseCase_TaskHistory_Call) Run(run func(ctx context.Context, principal model.Principal, id string, param model.TaskHistoryParam)) *TaskUseCase_TaskHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(model.TaskHistoryParam))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_TaskHistory_Call) Return(res []model.TaskEvent, size int, err error) *TaskUseCase_TaskHistory_Call {
+	_c.Call.Return(res, size, err)
+	return _c
+}
+
+func (_c *TaskUseCase_TaskHistory_Call) RunAndReturn(run func(context.Context, model.Principal, string, model.TaskHistoryParam) ([]model.TaskEvent, int, error)) *TaskUseCase_TaskHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateChecklistItem provides a mock function with given fields: ctx, principal, id, itemID, version, patch
+func (_m *TaskUseCase) UpdateChecklistItem(ctx context.Context, principal model.Principal, id string, itemID string, version int64, patch model.ChecklistItemPatchParam) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, itemID, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateChecklistItem")
+	}
+
+	var r0 *model.TaskResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string, int64, model.ChecklistItemPatchParam) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, itemID, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, string, int64, model.ChecklistItemPatchParam) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, itemID, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, string, int64, model.ChecklistItemPatchParam) error); ok {
+		r1 = rf(ctx, principal, id, itemID, version, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskUseCase_UpdateChecklistItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateChecklistItem'
+type TaskUseCase_UpdateChecklistItem_Call struct {
+	*mock.Call
+}
+
+// UpdateChecklistItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - itemID string
+//   - version int64
+//   - patch model.ChecklistItemPatchParam
+func (_e *TaskUseCase_Expecter) UpdateChecklistItem(ctx interface{}, principal interface{}, id interface{}, itemID interface{}, version interface{}, patch interface{}) *TaskUseCase_UpdateChecklistItem_Call {
+	return &TaskUseCase_UpdateChecklistItem_Call{Call: _e.mock.On("UpdateChecklistItem", ctx, principal, id, itemID, version, patch)}
+}
+
+func (_c *TaskUseCase_UpdateChecklistItem_Call) Run(run func(ctx context.Context, principal model.Principal, id string, itemID string, version int64, patch model.ChecklistItemPatchParam)) *TaskUseCase_UpdateChecklistItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(string), args[4].(int64), args[5].(model.ChecklistItemPatchParam))
+	})
+	return _c
+}
+
+func (_c *TaskUseCase_UpdateChecklistItem_Call) Return(res *model.TaskResponse, err error) *TaskUseCase_UpdateChecklistItem_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskUseCase_UpdateChecklistItem_Call) RunAndReturn(run func(context.Context, model.Principal, string, string, int64, model.ChecklistItemPatchParam) (*model.TaskResponse, error)) *TaskUseCase_UpdateChecklistItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateTask provides a mock function with given fields: ctx, id, body
-func (_m *TaskUseCase) UpdateTask(ctx context.Context, id string, body model.TaskBodyParam) (*model.TaskResponse, error) {
-	ret := _m.Called(ctx, id, body)
+// UpdateTask provides a mock function with given fields: ctx, principal, id, version, body
+func (_m *TaskUseCase) UpdateTask(ctx context.Context, principal model.Principal, id string, version int64, body model.TaskBodyParam) (*model.TaskResponse, error) {
+	ret := _m.Called(ctx, principal, id, version, body)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTask")
@@ -264,19 +1086,19 @@ func (_m *TaskUseCase) UpdateTask(ctx context.Context, id string, body model.Tas
 
 	var r0 *model.TaskResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, model.TaskBodyParam) (*model.TaskResponse, error)); ok {
-		return rf(ctx, id, body)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, model.TaskBodyParam) (*model.TaskResponse, error)); ok {
+		return rf(ctx, principal, id, version, body)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, model.TaskBodyParam) *model.TaskResponse); ok {
-		r0 = rf(ctx, id, body)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, int64, model.TaskBodyParam) *model.TaskResponse); ok {
+		r0 = rf(ctx, principal, id, version, body)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.TaskResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, model.TaskBodyParam) error); ok {
-		r1 = rf(ctx, id, body)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, int64, model.TaskBodyParam) error); ok {
+		r1 = rf(ctx, principal, id, version, body)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -291,15 +1113,17 @@ type TaskUseCase_UpdateTask_Call struct {
 
 // UpdateTask is a helper method to define mock.On call
 //   - ctx context.Context
+//   - principal model.Principal
 //   - id string
+//   - version int64
 //   - body model.TaskBodyParam
-func (_e *TaskUseCase_Expecter) UpdateTask(ctx interface{}, id interface{}, body interface{}) *TaskUseCase_UpdateTask_Call {
-	return &TaskUseCase_UpdateTask_Call{Call: _e.mock.On("UpdateTask", ctx, id, body)}
+func (_e *TaskUseCase_Expecter) UpdateTask(ctx interface{}, principal interface{}, id interface{}, version interface{}, body interface{}) *TaskUseCase_UpdateTask_Call {
+	return &TaskUseCase_UpdateTask_Call{Call: _e.mock.On("UpdateTask", ctx, principal, id, version, body)}
 }
 
-func (_c *TaskUseCase_UpdateTask_Call) Run(run func(ctx context.Context, id string, body model.TaskBodyParam)) *TaskUseCase_UpdateTask_Call {
+func (_c *TaskUseCase_UpdateTask_Call) Run(run func(ctx context.Context, principal model.Principal, id string, version int64, body model.TaskBodyParam)) *TaskUseCase_UpdateTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(model.TaskBodyParam))
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(int64), args[4].(model.TaskBodyParam))
 	})
 	return _c
 }
@@ -309,7 +1133,7 @@ func (_c *TaskUseCase_UpdateTask_Call) Return(res *model.TaskResponse, err error
 	return _c
 }
 
-func (_c *TaskUseCase_UpdateTask_Call) RunAndReturn(run func(context.Context, string, model.TaskBodyParam) (*model.TaskResponse, error)) *TaskUseCase_UpdateTask_Call {
+func (_c *TaskUseCase_UpdateTask_Call) RunAndReturn(run func(context.Context, model.Principal, string, int64, model.TaskBodyParam) (*model.TaskResponse, error)) *TaskUseCase_UpdateTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
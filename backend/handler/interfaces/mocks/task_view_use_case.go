@@ -0,0 +1,326 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+)
+
+// TaskViewUseCase is an autogenerated mock type for the TaskViewUseCase type
+type TaskViewUseCase struct {
+	mock.Mock
+}
+
+type TaskViewUseCase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TaskViewUseCase) EXPECT() *TaskViewUseCase_Expecter {
+	return &TaskViewUseCase_Expecter{mock: &_m.Mock}
+}
+
+// CreateView provides a mock function with given fields: ctx, principal, body
+func (_m *TaskViewUseCase) CreateView(ctx context.Context, principal model.Principal, body model.TaskViewBodyParam) (*model.TaskView, error) {
+	ret := _m.Called(ctx, principal, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateView")
+	}
+
+	var r0 *model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskViewBodyParam) (*model.TaskView, error)); ok {
+		return rf(ctx, principal, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, model.TaskViewBodyParam) *model.TaskView); ok {
+		r0 = rf(ctx, principal, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, model.TaskViewBodyParam) error); ok {
+		r1 = rf(ctx, principal, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewUseCase_CreateView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateView'
+type TaskViewUseCase_CreateView_Call struct {
+	*mock.Call
+}
+
+// CreateView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - body model.TaskViewBodyParam
+func (_e *TaskViewUseCase_Expecter) CreateView(ctx interface{}, principal interface{}, body interface{}) *TaskViewUseCase_CreateView_Call {
+	return &TaskViewUseCase_CreateView_Call{Call: _e.mock.On("CreateView", ctx, principal, body)}
+}
+
+func (_c *TaskViewUseCase_CreateView_Call) Run(run func(ctx context.Context, principal model.Principal, body model.TaskViewBodyParam)) *TaskViewUseCase_CreateView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(model.TaskViewBodyParam))
+	})
+	return _c
+}
+
+func (_c *TaskViewUseCase_CreateView_Call) Return(res *model.TaskView, err error) *TaskViewUseCase_CreateView_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskViewUseCase_CreateView_Call) RunAndReturn(run func(context.Context, model.Principal, model.TaskViewBodyParam) (*model.TaskView, error)) *TaskViewUseCase_CreateView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteView provides a mock function with given fields: ctx, principal, id
+func (_m *TaskViewUseCase) DeleteView(ctx context.Context, principal model.Principal, id string) error {
+	ret := _m.Called(ctx, principal, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) error); ok {
+		r0 = rf(ctx, principal, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskViewUseCase_DeleteView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteView'
+type TaskViewUseCase_DeleteView_Call struct {
+	*mock.Call
+}
+
+// DeleteView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+func (_e *TaskViewUseCase_Expecter) DeleteView(ctx interface{}, principal interface{}, id interface{}) *TaskViewUseCase_DeleteView_Call {
+	return &TaskViewUseCase_DeleteView_Call{Call: _e.mock.On("DeleteView", ctx, principal, id)}
+}
+
+func (_c *TaskViewUseCase_DeleteView_Call) Run(run func(ctx context.Context, principal model.Principal, id string)) *TaskViewUseCase_DeleteView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TaskViewUseCase_DeleteView_Call) Return(err error) *TaskViewUseCase_DeleteView_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskViewUseCase_DeleteView_Call) RunAndReturn(run func(context.Context, model.Principal, string) error) *TaskViewUseCase_DeleteView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetView provides a mock function with given fields: ctx, principal, id
+func (_m *TaskViewUseCase) GetView(ctx context.Context, principal model.Principal, id string) (*model.TaskView, error) {
+	ret := _m.Called(ctx, principal, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetView")
+	}
+
+	var r0 *model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) (*model.TaskView, error)); ok {
+		return rf(ctx, principal, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string) *model.TaskView); ok {
+		r0 = rf(ctx, principal, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string) error); ok {
+		r1 = rf(ctx, principal, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewUseCase_GetView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetView'
+type TaskViewUseCase_GetView_Call struct {
+	*mock.Call
+}
+
+// GetView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+func (_e *TaskViewUseCase_Expecter) GetView(ctx interface{}, principal interface{}, id interface{}) *TaskViewUseCase_GetView_Call {
+	return &TaskViewUseCase_GetView_Call{Call: _e.mock.On("GetView", ctx, principal, id)}
+}
+
+func (_c *TaskViewUseCase_GetView_Call) Run(run func(ctx context.Context, principal model.Principal, id string)) *TaskViewUseCase_GetView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TaskViewUseCase_GetView_Call) Return(res *model.TaskView, err error) *TaskViewUseCase_GetView_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskViewUseCase_GetView_Call) RunAndReturn(run func(context.Context, model.Principal, string) (*model.TaskView, error)) *TaskViewUseCase_GetView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListViews provides a mock function with given fields: ctx, principal
+func (_m *TaskViewUseCase) ListViews(ctx context.Context, principal model.Principal) ([]model.TaskView, error) {
+	ret := _m.Called(ctx, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListViews")
+	}
+
+	var r0 []model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal) ([]model.TaskView, error)); ok {
+		return rf(ctx, principal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal) []model.TaskView); ok {
+		r0 = rf(ctx, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal) error); ok {
+		r1 = rf(ctx, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewUseCase_ListViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListViews'
+type TaskViewUseCase_ListViews_Call struct {
+	*mock.Call
+}
+
+// ListViews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+func (_e *TaskViewUseCase_Expecter) ListViews(ctx interface{}, principal interface{}) *TaskViewUseCase_ListViews_Call {
+	return &TaskViewUseCase_ListViews_Call{Call: _e.mock.On("ListViews", ctx, principal)}
+}
+
+func (_c *TaskViewUseCase_ListViews_Call) Run(run func(ctx context.Context, principal model.Principal)) *TaskViewUseCase_ListViews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal))
+	})
+	return _c
+}
+
+func (_c *TaskViewUseCase_ListViews_Call) Return(res []model.TaskView, err error) *TaskViewUseCase_ListViews_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskViewUseCase_ListViews_Call) RunAndReturn(run func(context.Context, model.Principal) ([]model.TaskView, error)) *TaskViewUseCase_ListViews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateView provides a mock function with given fields: ctx, principal, id, body
+func (_m *TaskViewUseCase) UpdateView(ctx context.Context, principal model.Principal, id string, body model.TaskViewBodyParam) (*model.TaskView, error) {
+	ret := _m.Called(ctx, principal, id, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateView")
+	}
+
+	var r0 *model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, model.TaskViewBodyParam) (*model.TaskView, error)); ok {
+		return rf(ctx, principal, id, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Principal, string, model.TaskViewBodyParam) *model.TaskView); ok {
+		r0 = rf(ctx, principal, id, body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Principal, string, model.TaskViewBodyParam) error); ok {
+		r1 = rf(ctx, principal, id, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewUseCase_UpdateView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateView'
+type TaskViewUseCase_UpdateView_Call struct {
+	*mock.Call
+}
+
+// UpdateView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - principal model.Principal
+//   - id string
+//   - body model.TaskViewBodyParam
+func (_e *TaskViewUseCase_Expecter) UpdateView(ctx interface{}, principal interface{}, id interface{}, body interface{}) *TaskViewUseCase_UpdateView_Call {
+	return &TaskViewUseCase_UpdateView_Call{Call: _e.mock.On("UpdateView", ctx, principal, id, body)}
+}
+
+func (_c *TaskViewUseCase_UpdateView_Call) Run(run func(ctx context.Context, principal model.Principal, id string, body model.TaskViewBodyParam)) *TaskViewUseCase_UpdateView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(model.Principal), args[2].(string), args[3].(model.TaskViewBodyParam))
+	})
+	return _c
+}
+
+func (_c *TaskViewUseCase_UpdateView_Call) Return(res *model.TaskView, err error) *TaskViewUseCase_UpdateView_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskViewUseCase_UpdateView_Call) RunAndReturn(run func(context.Context, model.Principal, string, model.TaskViewBodyParam) (*model.TaskView, error)) *TaskViewUseCase_UpdateView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTaskViewUseCase creates a new instance of TaskViewUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskViewUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskViewUseCase {
+	mock := &TaskViewUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
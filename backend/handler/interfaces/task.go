@@ -7,9 +7,26 @@ import (
 
 //go:generate mockery --name=TaskUseCase --keeptree --output=mocks --case=underscore --with-expecter=true
 type TaskUseCase interface {
-	ListTask(ctx context.Context, param model.TaskListParam) (res []model.TaskResponse, size int, err error)
-	GetTask(ctx context.Context, id string) (res *model.TaskResponse, err error)
-	CreateTask(ctx context.Context, body model.TaskBodyParam) (res *model.TaskResponse, err error)
-	UpdateTask(ctx context.Context, id string, body model.TaskBodyParam) (res *model.TaskResponse, err error)
-	DeleteTask(ctx context.Context, id string) (err error)
+	ListTask(ctx context.Context, principal model.Principal, param model.TaskListParam) (res []model.TaskResponse, size int, err error)
+	// ListTaskCursor reads the list from param.Cursor, next is empty after the last page
+	ListTaskCursor(ctx context.Context, principal model.Principal, param model.TaskListParam) (res []model.TaskResponse, next string, err error)
+	GetTask(ctx context.Context, principal model.Principal, id string) (res *model.TaskResponse, err error)
+	CreateTask(ctx context.Context, principal model.Principal, body model.TaskBodyParam) (res *model.TaskResponse, err error)
+	// version is the one the client read (If-Match) for UpdateTask, PatchTask and DeleteTask, 0 skips the check
+	UpdateTask(ctx context.Context, principal model.Principal, id string, version int64, body model.TaskBodyParam) (res *model.TaskResponse, err error)
+	PatchTask(ctx context.Context, principal model.Principal, id string, version int64, patch model.TaskPatchParam) (res *model.TaskResponse, err error)
+	DeleteTask(ctx context.Context, principal model.Principal, id string, version int64) (err error)
+	ListTrash(ctx context.Context, principal model.Principal, param model.TaskTrashParam) (res []model.TaskResponse, size int, err error)
+	RestoreTask(ctx context.Context, principal model.Principal, id string) (res *model.TaskResponse, err error)
+	TaskHistory(ctx context.Context, principal model.Principal, id string, param model.TaskHistoryParam) (res []model.TaskEvent, size int, err error)
+	// the checklist methods take the task version like UpdateTask and return the whole task
+	AddChecklistItem(ctx context.Context, principal model.Principal, id string, version int64, body model.ChecklistItemBodyParam) (res *model.TaskResponse, err error)
+	UpdateChecklistItem(ctx context.Context, principal model.Principal, id, itemID string, version int64, patch model.ChecklistItemPatchParam) (res *model.TaskResponse, err error)
+	ReorderChecklist(ctx context.Context, principal model.Principal, id string, version int64, ids []string) (res *model.TaskResponse, err error)
+	RemoveChecklistItem(ctx context.Context, principal model.Principal, id, itemID string, version int64) (res *model.TaskResponse, err error)
+	// AddDependency marks the task as blocked by blockerID, RemoveDependency lifts it
+	AddDependency(ctx context.Context, principal model.Principal, id string, version int64, blockerID string) (res *model.TaskResponse, err error)
+	RemoveDependency(ctx context.Context, principal model.Principal, id, blockerID string, version int64) (res *model.TaskResponse, err error)
+	ListTags(ctx context.Context, principal model.Principal) (res []model.TagCount, err error)
+	RenameTag(ctx context.Context, principal model.Principal, from, to string) (res *model.TagRenameResponse, err error)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+)
+
+//go:generate mockery --name=TaskViewUseCase --keeptree --output=mocks --case=underscore --with-expecter=true
+type TaskViewUseCase interface {
+	ListViews(ctx context.Context, principal model.Principal) (res []model.TaskView, err error)
+	// GetView returns a view the caller owns or that is shared
+	GetView(ctx context.Context, principal model.Principal, id string) (res *model.TaskView, err error)
+	CreateView(ctx context.Context, principal model.Principal, body model.TaskViewBodyParam) (res *model.TaskView, err error)
+	UpdateView(ctx context.Context, principal model.Principal, id string, body model.TaskViewBodyParam) (res *model.TaskView, err error)
+	DeleteView(ctx context.Context, principal model.Principal, id string) (err error)
+}
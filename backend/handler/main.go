@@ -4,31 +4,64 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/hendrihmwn/crud-task-backend/handler/interfaces"
 	"github.com/hendrihmwn/crud-task-backend/helper"
+	"github.com/hendrihmwn/crud-task-backend/repository/memory"
 	mongo2 "github.com/hendrihmwn/crud-task-backend/repository/mongo"
 	"github.com/hendrihmwn/crud-task-backend/usecase"
+	usecaseInterfaces "github.com/hendrihmwn/crud-task-backend/usecase/interfaces"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 var InstanceHandler MainInstance
 
 type MainInstance struct {
-	clientMongo *mongo.Client
-	taskUseCase interfaces.TaskUseCase
-	authUseCase interfaces.AuthUseCase
-	config      helper.Config
+	clientMongo     *mongo.Client
+	taskUseCase     interfaces.TaskUseCase
+	authUseCase     interfaces.AuthUseCase
+	apiTokenUseCase interfaces.APITokenUseCase
+	taskViewUseCase interfaces.TaskViewUseCase
+	config          helper.Config
 }
 
-func InitHandler(router *gin.Engine, client *mongo.Client, config helper.Config) {
+// InitHandler registers the routes and returns the task use case, whose trash
+// purge the caller runs for as long as the server lives
+func InitHandler(router *gin.Engine, client *mongo.Client, config helper.Config) usecase.TaskUseCase {
 	taskMongoRepository := mongo2.NewTaskRepository(client, config.DBName, config.CollectionName)
-	taskUseCase := usecase.NewTaskUseCase(taskMongoRepository)
-	authUseCase := usecase.NewAuthUseCase(config)
+	taskEventMongoRepository := mongo2.NewTaskEventRepository(client, config.DBName, config.TaskEventCollection)
+	userMongoRepository := mongo2.NewUserRepository(client, config.DBName, config.UserCollection)
+	refreshTokenMongoRepository := mongo2.NewRefreshTokenRepository(client, config.DBName, config.RefreshTokenCollection)
+	apiTokenMongoRepository := mongo2.NewAPITokenRepository(client, config.DBName, config.APITokenCollection)
+	taskViewMongoRepository := mongo2.NewTaskViewRepository(client, config.DBName, config.TaskViewCollection)
+	loginAttemptRepository := newLoginAttemptRepository(client, config)
+	taskUseCase := usecase.NewTaskUseCase(taskMongoRepository, taskEventMongoRepository, config.TaskWorkflow)
+	authUseCase := usecase.NewAuthUseCase(config, userMongoRepository, refreshTokenMongoRepository, loginAttemptRepository)
+	apiTokenUseCase := usecase.NewAPITokenUseCase(apiTokenMongoRepository, userMongoRepository)
+	taskViewUseCase := usecase.NewTaskViewUseCase(taskViewMongoRepository)
 
 	InstanceHandler = MainInstance{
-		clientMongo: client,
-		taskUseCase: taskUseCase,
-		authUseCase: authUseCase,
-		config:      config,
+		clientMongo:     client,
+		taskUseCase:     taskUseCase,
+		authUseCase:     authUseCase,
+		apiTokenUseCase: apiTokenUseCase,
+		taskViewUseCase: taskViewUseCase,
+		config:          config,
 	}
+	router.Use(RequestID(), ErrorHandler())
+	router.NoRoute(func(c *gin.Context) {
+		c.Error(errRouteNotFound)
+	})
 	registerTaskHandler(router)
+	registerTagHandler(router)
 	registerAuthHandler(router)
+	registerAPITokenHandler(router)
+	registerTaskViewHandler(router)
+	return taskUseCase
+}
+
+// failed logins are counted in Mongo unless LOGIN_ATTEMPT_STORE=memory, which
+// only works when a single instance is running
+func newLoginAttemptRepository(client *mongo.Client, config helper.Config) usecaseInterfaces.LoginAttemptRepository {
+	if config.LoginAttemptStore == "memory" {
+		return memory.NewLoginAttemptRepository()
+	}
+	return mongo2.NewLoginAttemptRepository(client, config.DBName, config.LoginAttemptCollection)
 }
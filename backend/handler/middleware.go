@@ -1,81 +1,212 @@
 package handler
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/hendrihmwn/crud-task-backend/helper"
+	"github.com/hendrihmwn/crud-task-backend/model"
 	"net/http"
-	"reflect"
 	"strings"
 )
 
+// error codes returned by AuthMiddleware, the frontend refreshes the session
+// on token_expired and sends the user back to login on the others
+const (
+	codeTokenMissing = "token_missing"
+	codeTokenExpired = "token_expired"
+	codeTokenInvalid = "token_invalid"
+)
+
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.GetHeader("Authorization")
 		if auth == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+			abort(c, errTokenMissing)
 			return
 		}
-		// parse token, verify signature
-		jwtSecret := InstanceHandler.config.JWTSecret
+
 		tokenString := strings.TrimPrefix(auth, "Bearer ")
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
+		if strings.HasPrefix(tokenString, model.APITokenPrefix) {
+			p, err := InstanceHandler.apiTokenUseCase.Authenticate(c, tokenString)
+			if err != nil {
+				// a failing lookup is not the client's fault
+				if errorStatus(err) != http.StatusInternalServerError {
+					err = errTokenInvalid
+				}
+				abort(c, err)
+				return
+			}
+			c.Set("user_id", p.UserID)
+			c.Set("role", p.Role)
+			c.Set("scopes", p.Scopes)
+			c.Next()
+			return
+		}
 
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+		claims, err := parseAccessToken(InstanceHandler.config, tokenString)
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			abort(c, errTokenExpired)
 			return
 		}
+		if err != nil {
+			abort(c, errTokenInvalid)
+			return
+		}
+
+		// every task query is scoped to the token subject
+		sub, err := claims.GetSubject()
+		if err != nil || sub == "" {
+			abort(c, errTokenInvalid)
+			return
+		}
+		role, _ := claims["role"].(string)
+		if role == "" {
+			role = model.RoleMember
+		}
+		c.Set("user_id", sub)
+		c.Set("role", role)
 		c.Next()
 	}
 }
 
-func ValidationErrorHandler(obj interface{}) gin.HandlerFunc {
+// parseAccessToken verifies the signature with the key named by "kid" and
+// requires exp, iat and nbf plus the configured issuer and audience, allowing for clock skew
+func parseAccessToken(config helper.Config, tokenString string) (jwt.MapClaims, error) {
+	keys := config.SigningKeys()
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(keys.Methods()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(config.JWTIssuer),
+		jwt.WithAudience(config.JWTAudience),
+		jwt.WithLeeway(config.JWTClockSkew),
+	)
+
+	claims := jwt.MapClaims{}
+	_, err := parser.ParseWithClaims(tokenString, claims, keys.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+
+	// the parser only checks iat and nbf when they are present
+	for _, name := range []string{"iat", "nbf"} {
+		if _, ok := claims[name]; !ok {
+			return nil, fmt.Errorf("%w: %s claim is required", jwt.ErrTokenRequiredClaimMissing, name)
+		}
+	}
+	return claims, nil
+}
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+)
+
+// RequestID tags every request with an id that is echoed in the X-Request-ID
+// header and in error responses. An id sent by a proxy is kept when it looks sane.
+func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
 		c.Next()
+	}
+}
 
-		if len(c.Errors) > 0 {
-			err := c.Errors.Last().Err
-			message := FormatValidationError(err, obj)
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// TaskAuthorization must run after AuthMiddleware. Viewers are read-only,
+// members and admins may write; ownership is enforced by the task use case.
+func TaskAuthorization() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.GetString("role") {
+		case model.RoleAdmin, model.RoleMember:
+			c.Next()
+		case model.RoleViewer:
+			if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+				abort(c, errForbidden)
+				return
+			}
+			c.Next()
+		default:
+			abort(c, errForbidden)
+		}
+	}
+}
 
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": message,
+// RequireScope rejects personal access tokens that were not granted scope,
+// session JWTs are not limited by scopes
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !principal(c).HasScope(scope) {
+			abort(c, &apiError{
+				Status:  http.StatusForbidden,
+				Code:    codeInsufficientScope,
+				Message: "token is missing scope " + scope,
 			})
-			c.Abort()
 			return
 		}
+		c.Next()
 	}
 }
 
-func FormatValidationError(err error, obj interface{}) string {
-	if errs, ok := err.(validator.ValidationErrors); ok {
-		var messages []string
-
-		for _, e := range errs {
-			field, _ := reflect.TypeOf(obj).FieldByName(e.Field())
-			jsonTag := field.Tag.Get("json")
-
-			switch e.Tag() {
-			case "required":
-				messages = append(messages, fmt.Sprintf("%s is required", jsonTag))
-			case "max":
-				messages = append(messages, fmt.Sprintf("%s must be at most %s characters", jsonTag, e.Param()))
-			default:
-				messages = append(messages, fmt.Sprintf("Invalid %s", jsonTag))
-			}
+// SessionOnly rejects requests authenticated with a personal access token
+func SessionOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("scopes"); ok {
+			abort(c, errSessionRequired)
+			return
 		}
-
-		return strings.Join(messages, ", ")
+		c.Next()
 	}
+}
 
-	return err.Error()
+// principal returns the authenticated caller set by AuthMiddleware
+func principal(c *gin.Context) model.Principal {
+	p := model.Principal{
+		UserID: c.GetString("user_id"),
+		Role:   c.GetString("role"),
+	}
+	if scopes, ok := c.Get("scopes"); ok {
+		p.Scopes, _ = scopes.([]string)
+		if p.Scopes == nil {
+			p.Scopes = []string{}
+		}
+	}
+	return p
 }
 
 func MockToken() gin.HandlerFunc {
+	return MockTokenWithRole(model.RoleMember)
+}
+
+func MockTokenWithRole(role string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Set("user_id", "test-user")
+		c.Set("role", role)
 		c.Next()
 	}
 }
@@ -0,0 +1,395 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hendrihmwn/crud-task-backend/handler/interfaces/mocks"
+	"github.com/hendrihmwn/crud-task-backend/helper"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+	Config helper.Config
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.Config = helper.Config{
+		JWTSecret:    "secret",
+		JWTIssuer:    "crud-task-backend",
+		JWTAudience:  "crud-task",
+		JWTClockSkew: 30 * time.Second,
+	}
+	InstanceHandler = MainInstance{config: suite.Config}
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+func (suite *MiddlewareTestSuite) sign(method jwt.SigningMethod, key interface{}, mutate func(claims jwt.MapClaims)) string {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":  suite.Config.JWTIssuer,
+		"aud":  suite.Config.JWTAudience,
+		"sub":  "user-1",
+		"role": "member",
+		"iat":  now.Unix(),
+		"nbf":  now.Unix(),
+		"exp":  now.Add(time.Minute).Unix(),
+	}
+	mutate(claims)
+	signed, _ := jwt.NewWithClaims(method, claims).SignedString(key)
+	return signed
+}
+
+func (suite *MiddlewareTestSuite) TestAuthMiddleware() {
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test", AuthMiddleware(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetString("user_id"), "role": c.GetString("role")})
+	})
+
+	secret := []byte(suite.Config.JWTSecret)
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:     "error - missing token",
+			token:    "",
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenMissing,
+		},
+		{
+			name:     "error - malformed token",
+			token:    "not-a-jwt",
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name:     "error - wrong signature",
+			token:    suite.sign(jwt.SigningMethodHS256, []byte("other"), func(claims jwt.MapClaims) {}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name:     "error - unexpected algorithm",
+			token:    suite.sign(jwt.SigningMethodHS512, secret, func(claims jwt.MapClaims) {}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name:     "error - none algorithm",
+			token:    suite.sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, func(claims jwt.MapClaims) {}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "error - expired",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenExpired,
+		},
+		{
+			name: "error - missing exp",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				delete(claims, "exp")
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "error - missing iat",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				delete(claims, "iat")
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "error - missing nbf",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				delete(claims, "nbf")
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "error - not valid yet",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				claims["nbf"] = time.Now().Add(time.Hour).Unix()
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "error - wrong issuer",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				claims["iss"] = "someone-else"
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "error - wrong audience",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				claims["aud"] = "another-app"
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "error - missing subject",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				delete(claims, "sub")
+			}),
+			wantCode: http.StatusUnauthorized,
+			wantErr:  codeTokenInvalid,
+		},
+		{
+			name: "success - expired within clock skew",
+			token: suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-10 * time.Second).Unix()
+			}),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "success",
+			token:    suite.sign(jwt.SigningMethodHS256, secret, func(claims jwt.MapClaims) {}),
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Set("Accept", "application/json")
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+
+			if tt.wantErr != "" {
+				var body model.ErrorResponse
+				_ = json.Unmarshal(w.Body.Bytes(), &body)
+				suite.Equal(tt.wantErr, body.Error.Code)
+			} else {
+				var body map[string]string
+				_ = json.Unmarshal(w.Body.Bytes(), &body)
+				suite.Equal("user-1", body["user_id"])
+				suite.Equal("member", body["role"])
+			}
+		})
+	}
+}
+
+func (suite *MiddlewareTestSuite) TestAuthMiddlewareKeyRotation() {
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	strangerKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	// "old" was rotated out and only its public key is still configured
+	keys, err := helper.NewTokenKeys("new",
+		&helper.TokenKey{ID: "new", Method: jwt.SigningMethodRS256, Private: newKey, Public: &newKey.PublicKey},
+		&helper.TokenKey{ID: "old", Method: jwt.SigningMethodRS256, Public: &oldKey.PublicKey},
+	)
+	suite.Require().NoError(err)
+	suite.Config.TokenKeys = keys
+	InstanceHandler = MainInstance{config: suite.Config}
+
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/test", AuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	withKid := func(kid string, key interface{}) string {
+		t := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss": suite.Config.JWTIssuer,
+			"aud": suite.Config.JWTAudience,
+			"sub": "user-1",
+			"iat": time.Now().Unix(),
+			"nbf": time.Now().Unix(),
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+		if kid != "" {
+			t.Header["kid"] = kid
+		}
+		signed, _ := t.SignedString(key)
+		return signed
+	}
+	active, err := keys.Sign(jwt.MapClaims{
+		"iss": suite.Config.JWTIssuer,
+		"aud": suite.Config.JWTAudience,
+		"sub": "user-1",
+		"iat": time.Now().Unix(),
+		"nbf": time.Now().Unix(),
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	suite.Require().NoError(err)
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "success - active key",
+			token:    active,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "success - retired key still verifies",
+			token:    withKid("old", oldKey),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "error - kid of another key",
+			token:    withKid("new", oldKey),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "error - unknown kid",
+			token:    withKid("stranger", strangerKey),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "error - missing kid",
+			token:    withKid("", newKey),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "error - hmac token once asymmetric keys are configured",
+			token:    suite.sign(jwt.SigningMethodHS256, []byte(suite.Config.JWTSecret), func(claims jwt.MapClaims) {}),
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
+
+func (suite *MiddlewareTestSuite) TestAuthMiddlewarePersonalAccessToken() {
+	apiTokenUseCase := mocks.NewAPITokenUseCase(suite.T())
+	InstanceHandler = MainInstance{config: suite.Config, apiTokenUseCase: apiTokenUseCase}
+
+	app := gin.New()
+	app.Use(ErrorHandler())
+	app.GET("/tasks", AuthMiddleware(), RequireScope(model.ScopeTasksRead), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	app.POST("/tasks", AuthMiddleware(), RequireScope(model.ScopeTasksWrite), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	app.GET("/tokens", AuthMiddleware(), SessionOnly(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	readOnly := model.Principal{UserID: "user-1", Role: model.RoleMember, Scopes: []string{model.ScopeTasksRead}}
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		mock     func()
+		wantCode int
+	}{
+		{
+			name:   "error - unknown token",
+			method: "GET",
+			path:   "/tasks",
+			token:  "pat_unknown",
+			mock: func() {
+				apiTokenUseCase.EXPECT().Authenticate(mock.Anything, "pat_unknown").
+					Return(model.Principal{}, usecase.ErrInvalidAPIToken).Once()
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "success - scope granted",
+			method: "GET",
+			path:   "/tasks",
+			token:  "pat_read",
+			mock: func() {
+				apiTokenUseCase.EXPECT().Authenticate(mock.Anything, "pat_read").
+					Return(readOnly, nil).Once()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "error - scope missing",
+			method: "POST",
+			path:   "/tasks",
+			token:  "pat_read",
+			mock: func() {
+				apiTokenUseCase.EXPECT().Authenticate(mock.Anything, "pat_read").
+					Return(readOnly, nil).Once()
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:   "error - token management needs a session",
+			method: "GET",
+			path:   "/tokens",
+			token:  "pat_read",
+			mock: func() {
+				apiTokenUseCase.EXPECT().Authenticate(mock.Anything, "pat_read").
+					Return(readOnly, nil).Once()
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "success - session jwt is not limited by scopes",
+			method:   "POST",
+			path:     "/tasks",
+			token:    suite.sign(jwt.SigningMethodHS256, []byte(suite.Config.JWTSecret), func(claims jwt.MapClaims) {}),
+			mock:     func() {},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "success - session jwt manages tokens",
+			method:   "GET",
+			path:     "/tokens",
+			token:    suite.sign(jwt.SigningMethodHS256, []byte(suite.Config.JWTSecret), func(claims jwt.MapClaims) {}),
+			mock:     func() {},
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// translators holds the validation messages, English is the fallback when the
+// client doesn't accept any of the supported languages
+var translators = ut.New(en.New(), en.New(), id.New())
+
+// messages for the custom validators, and for tags that have no translation
+var customTranslations = map[string]map[string]string{
+	"en": {
+		"notblank":      "{0} must not be blank",
+		"objectid":      "{0} must be a valid id",
+		"task_status":   "{0} must be one of [" + taskStatusList() + "]",
+		"task_priority": "{0} must be one of [" + taskPriorityList() + "]",
+		"task_tag":      "{0} must be up to 30 letters, digits, '.', '-' or '_'",
+		"task_sort":     "{0} must be a comma separated list of [" + strings.Join(model.TaskSortFields(), " ") + "], each at most once and prefixed with - to sort descending",
+		"invalid":       "{0} is invalid",
+	},
+	"id": {
+		"notblank":      "{0} tidak boleh kosong",
+		"objectid":      "{0} harus berupa id yang valid",
+		"task_status":   "{0} harus berupa salah satu dari [" + taskStatusList() + "]",
+		"task_priority": "{0} harus berupa salah satu dari [" + taskPriorityList() + "]",
+		"task_tag":      "{0} harus berupa paling banyak 30 huruf, angka, '.', '-' atau '_'",
+		"task_sort":     "{0} harus berupa daftar yang dipisahkan koma dari [" + strings.Join(model.TaskSortFields(), " ") + "], masing-masing paling banyak sekali dan diawali - untuk urutan menurun",
+		"invalid":       "{0} tidak valid",
+	},
+}
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	if err := registerValidation(v); err != nil {
+		log.Fatalf("Failed to register validation: %v", err)
+	}
+}
+
+func registerValidation(v *validator.Validate) error {
+	// validation errors name the field the way the client sent it
+	v.RegisterTagNameFunc(fieldName)
+
+	if err := v.RegisterValidation("notblank", notBlank); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("objectid", objectID); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("task_status", taskStatus); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("task_priority", taskPriority); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("task_tag", taskTag); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("task_sort", taskSort); err != nil {
+		return err
+	}
+
+	defaults := map[string]func(*validator.Validate, ut.Translator) error{
+		"en": enTranslations.RegisterDefaultTranslations,
+		"id": idTranslations.RegisterDefaultTranslations,
+	}
+	for locale, register := range defaults {
+		trans, _ := translators.GetTranslator(locale)
+		if err := register(v, trans); err != nil {
+			return err
+		}
+		for tag, text := range customTranslations[locale] {
+			if err := registerTranslation(v, trans, tag, text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func registerTranslation(v *validator.Validate, trans ut.Translator, tag, text string) error {
+	return v.RegisterTranslation(tag, trans, func(t ut.Translator) error {
+		return t.Add(tag, text, true)
+	}, func(t ut.Translator, fe validator.FieldError) string {
+		msg, _ := t.T(tag, fe.Field(), fe.Param())
+		return msg
+	})
+}
+
+// notBlank fails strings made of whitespace only, required lets those through
+func notBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+func objectID(fl validator.FieldLevel) bool {
+	return primitive.IsValidObjectID(fl.Field().String())
+}
+
+func taskStatus(fl validator.FieldLevel) bool {
+	return model.TaskStatus(fl.Field().String()).Valid()
+}
+
+func taskStatusList() string {
+	names := make([]string, len(model.TaskStatuses))
+	for i, status := range model.TaskStatuses {
+		names[i] = string(status)
+	}
+	return strings.Join(names, " ")
+}
+
+func taskPriority(fl validator.FieldLevel) bool {
+	return model.TaskPriority(fl.Field().String()).Valid()
+}
+
+func taskPriorityList() string {
+	names := make([]string, len(model.TaskPriorities))
+	for i, priority := range model.TaskPriorities {
+		names[i] = string(priority)
+	}
+	return strings.Join(names, " ")
+}
+
+// taskTag checks a tag as it will be stored, so "Backend " is accepted
+func taskTag(fl validator.FieldLevel) bool {
+	return model.ValidTag(fl.Field().String())
+}
+
+func taskSort(fl validator.FieldLevel) bool {
+	_, ok := model.ParseTaskSort(fl.Field().String())
+	return ok
+}
+
+// translator picks the messages for the languages in Accept-Language
+func translator(c *gin.Context) ut.Translator {
+	var locales []string
+	for _, lang := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		// "id-ID" and "en_US" are served by their base language, entries
+		// like "-" have none and are skipped
+		parts := strings.FieldsFunc(strings.Split(lang, ";")[0], func(r rune) bool {
+			return r == '-' || r == '_' || unicode.IsSpace(r)
+		})
+		if len(parts) == 0 {
+			continue
+		}
+		locales = append(locales, strings.ToLower(parts[0]))
+	}
+	// the fallback is english when no language is supported
+	trans, _ := translators.FindTranslator(locales...)
+	return trans
+}
+
+// bindError turns an error from c.ShouldBind* into a 400 that says which
+// fields are wrong, in the language of the client
+func bindError(c *gin.Context, err error) error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		trans := translator(c)
+		details := make([]model.FieldError, 0, len(validationErrs))
+		messages := make([]string, 0, len(validationErrs))
+		for _, e := range validationErrs {
+			message := validationMessage(e, trans)
+			details = append(details, model.FieldError{Field: e.Field(), Code: e.Tag(), Param: e.Param(), Message: message})
+			messages = append(messages, message)
+		}
+		return &apiError{
+			Status:  http.StatusBadRequest,
+			Code:    codeValidationFailed,
+			Message: strings.Join(messages, ", "),
+			Details: details,
+		}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		message := fmt.Sprintf("%s must be %s", typeErr.Field, jsonTypeName(typeErr.Type.Kind()))
+		return &apiError{
+			Status:  http.StatusBadRequest,
+			Code:    codeValidationFailed,
+			Message: message,
+			Details: []model.FieldError{{Field: typeErr.Field, Code: "type", Message: message}},
+		}
+	}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return &apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: fmt.Sprintf("%q is not a valid number", numErr.Num)}
+	}
+
+	var timeErr *time.ParseError
+	if errors.As(err, &timeErr) {
+		return &apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: fmt.Sprintf("%q is not a valid RFC 3339 time", timeErr.Value)}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: "request body is not valid JSON"}
+	}
+
+	return &apiError{Status: http.StatusBadRequest, Code: codeInvalidRequest, Message: "invalid request"}
+}
+
+func validationMessage(e validator.FieldError, trans ut.Translator) string {
+	message := e.Translate(trans)
+	// Translate falls back to the raw validator error for unknown tags
+	if message == e.Error() {
+		message, _ = trans.T("invalid", e.Field())
+	}
+	return message
+}
+
+// jsonTypeName names a Go kind the way a JSON client would
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// fieldName is the json, form or uri name of a struct field
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
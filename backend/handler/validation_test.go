@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ValidationTestSuite struct {
+	suite.Suite
+	app *gin.Engine
+}
+
+func TestValidationTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidationTestSuite))
+}
+
+func (suite *ValidationTestSuite) SetupTest() {
+	suite.app = gin.New()
+	suite.app.Use(ErrorHandler())
+	suite.app.GET("/tasks", func(c *gin.Context) {
+		var param model.TaskListParam
+		if err := c.ShouldBind(&param); err != nil {
+			c.Error(bindError(c, err))
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	suite.app.PUT("/tasks/:id", func(c *gin.Context) {
+		var param model.TaskGetParam
+		if err := c.ShouldBindUri(&param); err != nil {
+			c.Error(bindError(c, err))
+			return
+		}
+		var body model.TaskBodyParam
+		if err := c.ShouldBind(&body); err != nil {
+			c.Error(bindError(c, err))
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+}
+
+func (suite *ValidationTestSuite) TestTaskValidation() {
+	validID := "68fc6a818c54acf4a737d7ab"
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		language    string
+		wantCode    int
+		wantDetails []model.FieldError
+	}{
+		{
+			name:     "list - valid",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&status=backlog&sort_by=title&order=-1",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - out of range and unknown values",
+			method:   "GET",
+			path:     "/tasks?page=0&limit=500&status=done&sort_by=owner_id&order=2",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "limit", Code: "lte", Param: "100", Message: "limit must be 100 or less"},
+				{Field: "status", Code: "task_status", Message: "status must be one of [backlog todo in_progress completed cancelled]"},
+				{Field: "sort_by", Code: "oneof", Param: "title status priority due_date created_at updated_at completed_at relevance", Message: "sort_by must be one of [title status priority due_date created_at updated_at completed_at relevance]"},
+				{Field: "order", Code: "oneof", Param: "1 -1", Message: "order must be one of [1 -1]"},
+			},
+		},
+		{
+			name:     "list - cursor",
+			method:   "GET",
+			path:     "/tasks?limit=10&cursor=eyJvIjoxfQ&sort_by=created_at",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - cursor with a page",
+			method:   "GET",
+			path:     "/tasks?page=2&limit=10&cursor=eyJvIjoxfQ",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "cursor", Code: "excluded_with", Param: "Page", Message: "cursor is an excluded field"},
+			},
+		},
+		{
+			name:     "list - multi-key sort",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&sort=-priority,created_at",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - sort on an unknown field",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&sort=-priority,owner_id",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "sort", Code: "task_sort", Message: "sort must be a comma separated list of [completed_at created_at due_date priority relevance status title updated_at], each at most once and prefixed with - to sort descending"},
+			},
+		},
+		{
+			name:     "list - sort repeats a field",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&sort=title,-title",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "sort", Code: "task_sort", Message: "sort must be a comma separated list of [completed_at created_at due_date priority relevance status title updated_at], each at most once and prefixed with - to sort descending"},
+			},
+		},
+		{
+			name:     "list - sort with sort_by",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&sort=title&sort_by=status",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "sort", Code: "excluded_with", Param: "SortBy", Message: "sort is an excluded field"},
+			},
+		},
+		{
+			name:     "list - filter",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&filter=status%3Ain(backlog%2Cin_progress)%20AND%20created_at%3E2026-01-01",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - malformed view",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&view=xxx",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "view", Code: "objectid", Message: "view must be a valid id"},
+			},
+		},
+		{
+			name:     "list - no limit",
+			method:   "GET",
+			path:     "/tasks?page=1",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "limit", Code: "required", Message: "limit is a required field"},
+			},
+		},
+		{
+			name:     "list - search modes",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&search=rel&search_mode=prefix",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - unknown search mode",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&search=rel&search_mode=regex",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "search_mode", Code: "oneof", Param: "text prefix", Message: "search_mode must be one of [text prefix]"},
+			},
+		},
+		{
+			name:     "list - due date filters",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&priority=urgent&due_after=2025-01-01T00:00:00Z&due_before=2025-02-01T00:00:00%2B07:00&overdue=true",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - unknown priority",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&priority=asap",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "priority", Code: "task_priority", Message: "priority must be one of [low medium high urgent]"},
+			},
+		},
+		{
+			name:     "list - due date is not a time",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&due_before=tomorrow",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "update - valid",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "description": "description", "status": "completed"}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "update - priority and due date",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "status": "todo", "priority": "high", "due_date": "2025-01-31T17:00:00Z"}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - tags",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&tags=backend,bug&tag_match=all",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "list - unknown tag match",
+			method:   "GET",
+			path:     "/tasks?page=1&limit=10&tags=backend&tag_match=some",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "tag_match", Code: "oneof", Param: "any all", Message: "tag_match must be one of [any all]"},
+			},
+		},
+		{
+			name:     "update - tags",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "status": "todo", "tags": ["Backend", "infra-2"]}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "update - bad tag",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "status": "todo", "tags": ["backend", "front end"]}`,
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "tags[1]", Code: "task_tag", Message: "tags[1] must be up to 30 letters, digits, '.', '-' or '_'"},
+			},
+		},
+		{
+			name:     "update - due date is not a time",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "status": "todo", "due_date": "31/01/2025"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "update - malformed id",
+			method:   "PUT",
+			path:     "/tasks/xxx",
+			body:     `{"title": "title", "description": "description", "status": "completed"}`,
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "id", Code: "objectid", Message: "id must be a valid id"},
+			},
+		},
+		{
+			name:     "update - blank title and unknown status",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "   ", "description": "description", "status": "done"}`,
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "title", Code: "notblank", Message: "title must not be blank"},
+				{Field: "status", Code: "task_status", Message: "status must be one of [backlog todo in_progress completed cancelled]"},
+			},
+		},
+		{
+			name:     "update - indonesian messages",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "   ", "description": "description"}`,
+			language: "id-ID,id;q=0.9,en;q=0.8",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "title", Code: "notblank", Message: "title tidak boleh kosong"},
+				{Field: "status", Code: "required", Message: "status wajib diisi"},
+			},
+		},
+		{
+			name:     "update - unsupported language falls back to english",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "description": "description"}`,
+			language: "fr-FR",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "status", Code: "required", Message: "status is a required field"},
+			},
+		},
+		{
+			name:     "update - a language without a base falls back to english",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "description": "description"}`,
+			language: "-",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "status", Code: "required", Message: "status is a required field"},
+			},
+		},
+		{
+			name:     "update - a lone underscore falls back to english",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "description": "description"}`,
+			language: "_",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "status", Code: "required", Message: "status is a required field"},
+			},
+		},
+		{
+			name:     "update - entries without a base are skipped",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "description": "description"}`,
+			language: "-;q=0.9,id",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "status", Code: "required", Message: "status wajib diisi"},
+			},
+		},
+		{
+			name:     "update - supported language then an empty entry",
+			method:   "PUT",
+			path:     "/tasks/" + validID,
+			body:     `{"title": "title", "description": "description"}`,
+			language: "en,-",
+			wantCode: http.StatusBadRequest,
+			wantDetails: []model.FieldError{
+				{Field: "status", Code: "required", Message: "status is a required field"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.language != "" {
+				req.Header.Set("Accept-Language", tt.language)
+			}
+			suite.app.ServeHTTP(w, req)
+			suite.Equal(tt.wantCode, w.Code, fmt.Sprint(w.Body))
+
+			if tt.wantDetails != nil {
+				var res model.ErrorResponse
+				suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+				suite.Equal(codeValidationFailed, res.Error.Code)
+				suite.Equal(tt.wantDetails, res.Error.Details)
+			}
+		})
+	}
+}
@@ -1,16 +1,42 @@
 package helper
 
 import (
+	"github.com/hendrihmwn/crud-task-backend/model"
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	MongoDBUrl     string
-	JWTSecret      string
-	DBName         string
-	CollectionName string
+	MongoDBUrl             string
+	JWTSecret              string
+	DBName                 string
+	CollectionName         string
+	UserCollection         string
+	RefreshTokenCollection string
+	APITokenCollection     string
+	LoginAttemptCollection string
+	TaskEventCollection    string
+	TaskViewCollection     string
+	AccessTokenTTL         time.Duration
+	RefreshTokenTTL        time.Duration
+	JWTIssuer              string
+	JWTAudience            string
+	JWTClockSkew           time.Duration
+	TokenKeys              *TokenKeys
+	// LoginAttemptStore is "mongo" (shared by replicas) or "memory" (per process)
+	LoginAttemptStore     string
+	LoginMaxAttempts      int
+	LoginMaxAttemptsPerIP int
+	LoginBackoff          time.Duration
+	LoginLockout          time.Duration
+	TrustedProxies        []string
+	TaskWorkflow          model.TaskWorkflow
+	TrashRetention        time.Duration
+	TrashPurgeInterval    time.Duration
 }
 
 func LoadConfig() Config {
@@ -19,10 +45,100 @@ func LoadConfig() Config {
 		log.Println("No .env file found")
 	}
 
+	tokenKeys := NewHMACTokenKeys(os.Getenv("JWT_SECRET"))
+	if spec := os.Getenv("JWT_KEYS"); spec != "" {
+		tokenKeys, err = LoadTokenKeys(spec, os.Getenv("JWT_ACTIVE_KID"))
+		if err != nil {
+			log.Fatalf("Invalid JWT_KEYS: %v", err)
+		}
+	}
+
+	taskWorkflow := model.DefaultTaskWorkflow()
+	if spec := os.Getenv("TASK_WORKFLOW"); spec != "" {
+		taskWorkflow, err = ParseTaskWorkflow(spec)
+		if err != nil {
+			log.Fatalf("Invalid TASK_WORKFLOW: %v", err)
+		}
+	}
+
 	return Config{
-		MongoDBUrl:     os.Getenv("MONGODB_URL"),
-		JWTSecret:      os.Getenv("JWT_SECRET"),
-		DBName:         os.Getenv("DB_NAME"),
-		CollectionName: os.Getenv("COLLECTION_NAME"),
+		MongoDBUrl:             os.Getenv("MONGODB_URL"),
+		JWTSecret:              os.Getenv("JWT_SECRET"),
+		DBName:                 os.Getenv("DB_NAME"),
+		CollectionName:         os.Getenv("COLLECTION_NAME"),
+		UserCollection:         os.Getenv("USER_COLLECTION_NAME"),
+		RefreshTokenCollection: os.Getenv("REFRESH_TOKEN_COLLECTION_NAME"),
+		APITokenCollection:     os.Getenv("API_TOKEN_COLLECTION_NAME"),
+		LoginAttemptCollection: os.Getenv("LOGIN_ATTEMPT_COLLECTION_NAME"),
+		TaskEventCollection:    os.Getenv("TASK_EVENT_COLLECTION_NAME"),
+		TaskViewCollection:     os.Getenv("TASK_VIEW_COLLECTION_NAME"),
+		AccessTokenTTL:         getDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL:        getDuration("REFRESH_TOKEN_TTL", 7*24*time.Hour),
+		JWTIssuer:              getEnv("JWT_ISSUER", "crud-task-backend"),
+		JWTAudience:            getEnv("JWT_AUDIENCE", "crud-task"),
+		JWTClockSkew:           getDuration("JWT_CLOCK_SKEW", 30*time.Second),
+		TokenKeys:              tokenKeys,
+		LoginAttemptStore:      getEnv("LOGIN_ATTEMPT_STORE", "mongo"),
+		LoginMaxAttempts:       getInt("LOGIN_MAX_ATTEMPTS", 5),
+		LoginMaxAttemptsPerIP:  getInt("LOGIN_MAX_ATTEMPTS_PER_IP", 20),
+		LoginBackoff:           getDuration("LOGIN_BACKOFF", time.Second),
+		LoginLockout:           getDuration("LOGIN_LOCKOUT", 15*time.Minute),
+		TrustedProxies:         getList("TRUSTED_PROXIES"),
+		TaskWorkflow:           taskWorkflow,
+		TrashRetention:         getDuration("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval:     getDuration("TRASH_PURGE_INTERVAL", time.Hour),
+	}
+}
+
+// SigningKeys returns the configured token keys, or HS256 keys for a Config built by hand
+func (c Config) SigningKeys() *TokenKeys {
+	if c.TokenKeys == nil {
+		return NewHMACTokenKeys(c.JWTSecret)
+	}
+	return c.TokenKeys
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// getDuration reads a time.ParseDuration value (e.g. "15m"), falling back when unset or invalid
+func getDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %s", key, v, fallback)
+		return fallback
+	}
+	return d
+}
+
+func getInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %d", key, v, fallback)
+		return fallback
+	}
+	return n
+}
+
+// getList reads a comma separated value, skipping empty items
+func getList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
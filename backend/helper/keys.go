@@ -0,0 +1,191 @@
+package helper
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// TokenKey is one JWT key. Retired keys are loaded from a public key PEM and
+// have no Private part, so tokens they signed still verify during rotation.
+type TokenKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// TokenKeys signs access tokens with the active key and verifies them with
+// the key named by the token "kid" header. Without configured PEM keys it
+// falls back to HS256 with the shared JWT secret.
+type TokenKeys struct {
+	active *TokenKey
+	keys   map[string]*TokenKey
+	order  []string
+	secret []byte
+}
+
+func NewHMACTokenKeys(secret string) *TokenKeys {
+	return &TokenKeys{secret: []byte(secret)}
+}
+
+func NewTokenKeys(activeID string, keys ...*TokenKey) (*TokenKeys, error) {
+	k := &TokenKeys{keys: map[string]*TokenKey{}}
+	for _, key := range keys {
+		if _, ok := k.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		k.keys[key.ID] = key
+		k.order = append(k.order, key.ID)
+	}
+	if activeID == "" && len(k.order) > 0 {
+		activeID = k.order[0]
+	}
+	active, ok := k.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q is not configured", activeID)
+	}
+	if active.Private == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeID)
+	}
+	k.active = active
+	return k, nil
+}
+
+// LoadTokenKeys reads keys from a "kid=path.pem,kid2=path2.pem" list
+func LoadTokenKeys(spec, activeID string) (*TokenKeys, error) {
+	var keys []*TokenKey
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, path, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid key entry %q, expected kid=path", entry)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseTokenKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewTokenKeys(activeID, keys...)
+}
+
+// ParseTokenKey accepts PKCS#8 or PKCS#1 private keys and PKIX public keys, RSA or Ed25519
+func ParseTokenKey(kid string, data []byte) (*TokenKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &TokenKey{ID: kid}
+	switch v := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, v, &v.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodRS256, v
+	case ed25519.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, v, v.Public()
+	case ed25519.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodEdDSA, v
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	return key, nil
+}
+
+func (k *TokenKeys) Sign(claims jwt.Claims) (string, error) {
+	if k.active == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.secret)
+	}
+	t := jwt.NewWithClaims(k.active.Method, claims)
+	t.Header["kid"] = k.active.ID
+	return t.SignedString(k.active.Private)
+}
+
+// Methods lists the algorithms the verifier accepts
+func (k *TokenKeys) Methods() []string {
+	if k.active == nil {
+		return []string{jwt.SigningMethodHS256.Alg()}
+	}
+	var methods []string
+	seen := map[string]bool{}
+	for _, id := range k.order {
+		alg := k.keys[id].Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// Keyfunc picks the verification key by "kid" and rejects a token whose
+// algorithm doesn't belong to that key
+func (k *TokenKeys) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if k.active == nil {
+		return k.secret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("key %q does not sign %s", kid, token.Method.Alg())
+	}
+	return key.Public, nil
+}
+
+// JWKS publishes every asymmetric key, empty in HMAC mode since the secret must stay private
+func (k *TokenKeys) JWKS() model.JWKSet {
+	set := model.JWKSet{Keys: []model.JWK{}}
+	for _, id := range k.order {
+		key := k.keys[id]
+		jwk := model.JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
@@ -0,0 +1,146 @@
+package helper_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hendrihmwn/crud-task-backend/helper"
+	"github.com/stretchr/testify/suite"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type KeysTestSuite struct {
+	suite.Suite
+
+	Dir string
+}
+
+func TestKeysTestSuite(t *testing.T) {
+	suite.Run(t, new(KeysTestSuite))
+}
+
+func (s *KeysTestSuite) SetupTest() {
+	s.Dir = s.T().TempDir()
+}
+
+func (s *KeysTestSuite) writePEM(name, blockType string, der []byte) string {
+	path := filepath.Join(s.Dir, name)
+	s.Require().NoError(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func (s *KeysTestSuite) TestLoadTokenKeys() {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPub, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	pkcs8RSA, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	pkcs8Ed, _ := x509.MarshalPKCS8PrivateKey(edKey)
+	pkixEd, _ := x509.MarshalPKIXPublicKey(edPub)
+
+	pkcs1 := s.writePEM("pkcs1.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	rsaPath := s.writePEM("rsa.pem", "PRIVATE KEY", pkcs8RSA)
+	edPath := s.writePEM("ed.pem", "PRIVATE KEY", pkcs8Ed)
+	edPubPath := s.writePEM("ed.pub.pem", "PUBLIC KEY", pkixEd)
+	garbage := filepath.Join(s.Dir, "garbage.pem")
+	s.Require().NoError(os.WriteFile(garbage, []byte("not a key"), 0o600))
+
+	tests := []struct {
+		name        string
+		spec        string
+		active      string
+		wantErr     bool
+		wantMethods []string
+		wantKids    []string
+	}{
+		{
+			name:        "success - pkcs1 rsa",
+			spec:        "a=" + pkcs1,
+			wantMethods: []string{"RS256"},
+			wantKids:    []string{"a"},
+		},
+		{
+			name:        "success - rsa active with retired ed25519 public key",
+			spec:        "new=" + rsaPath + ",old=" + edPubPath,
+			active:      "new",
+			wantMethods: []string{"RS256", "EdDSA"},
+			wantKids:    []string{"new", "old"},
+		},
+		{
+			name:        "success - ed25519",
+			spec:        "ed=" + edPath,
+			active:      "ed",
+			wantMethods: []string{"EdDSA"},
+			wantKids:    []string{"ed"},
+		},
+		{
+			name:    "error - active key without private part",
+			spec:    "old=" + edPubPath,
+			active:  "old",
+			wantErr: true,
+		},
+		{
+			name:    "error - active key not configured",
+			spec:    "a=" + rsaPath,
+			active:  "b",
+			wantErr: true,
+		},
+		{
+			name:    "error - duplicate kid",
+			spec:    "a=" + rsaPath + ",a=" + edPath,
+			wantErr: true,
+		},
+		{
+			name:    "error - malformed entry",
+			spec:    rsaPath,
+			wantErr: true,
+		},
+		{
+			name:    "error - not a pem file",
+			spec:    "a=" + garbage,
+			wantErr: true,
+		},
+		{
+			name:    "error - missing file",
+			spec:    "a=" + filepath.Join(s.Dir, "missing.pem"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			keys, err := helper.LoadTokenKeys(tt.spec, tt.active)
+			if tt.wantErr {
+				s.Error(err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tt.wantMethods, keys.Methods())
+
+			var kids []string
+			for _, k := range keys.JWKS().Keys {
+				kids = append(kids, k.Kid)
+			}
+			s.Equal(tt.wantKids, kids)
+
+			// the active key must produce tokens its own Keyfunc accepts
+			signed, err := keys.Sign(jwt.MapClaims{"sub": "user-1"})
+			s.Require().NoError(err)
+			_, err = jwt.Parse(signed, keys.Keyfunc, jwt.WithValidMethods(keys.Methods()))
+			s.NoError(err)
+		})
+	}
+}
+
+func (s *KeysTestSuite) TestHMACTokenKeys() {
+	keys := helper.NewHMACTokenKeys("secret")
+	s.Equal([]string{"HS256"}, keys.Methods())
+	s.Empty(keys.JWKS().Keys)
+
+	signed, err := keys.Sign(jwt.MapClaims{"sub": "user-1"})
+	s.Require().NoError(err)
+	_, err = jwt.Parse(signed, keys.Keyfunc, jwt.WithValidMethods(keys.Methods()))
+	s.NoError(err)
+}
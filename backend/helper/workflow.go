@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"strings"
+)
+
+// ParseTaskWorkflow reads a transition graph written as
+// "backlog=todo|in_progress;todo=in_progress|completed". Statuses that are
+// not listed can't be left.
+func ParseTaskWorkflow(spec string) (model.TaskWorkflow, error) {
+	workflow := model.TaskWorkflow{}
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(rule, "=")
+		if !ok {
+			return nil, fmt.Errorf("rule %q must look like from=to|to", rule)
+		}
+		fromStatus := model.TaskStatus(strings.TrimSpace(from))
+		if !fromStatus.Valid() {
+			return nil, fmt.Errorf("unknown status %q", fromStatus)
+		}
+		if _, dup := workflow[fromStatus]; dup {
+			return nil, fmt.Errorf("status %q is listed twice", fromStatus)
+		}
+		targets := []model.TaskStatus{}
+		for _, t := range strings.Split(to, "|") {
+			status := model.TaskStatus(strings.TrimSpace(t))
+			if status == "" {
+				continue
+			}
+			if !status.Valid() {
+				return nil, fmt.Errorf("unknown status %q", status)
+			}
+			targets = append(targets, status)
+		}
+		workflow[fromStatus] = targets
+	}
+	return workflow, nil
+}
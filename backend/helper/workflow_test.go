@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type WorkflowTestSuite struct {
+	suite.Suite
+}
+
+func TestWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkflowTestSuite))
+}
+
+func (s *WorkflowTestSuite) TestParseTaskWorkflow() {
+	tests := []struct {
+		name       string
+		spec       string
+		want       model.TaskWorkflow
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "success",
+			spec: " backlog=todo|in_progress ; in_progress=completed; completed= ",
+			want: model.TaskWorkflow{
+				model.TaskStatusBacklog:    {model.TaskStatusTodo, model.TaskStatusInProgress},
+				model.TaskStatusInProgress: {model.TaskStatusCompleted},
+				model.TaskStatusCompleted:  {},
+			},
+		},
+		{
+			name:       "error - unknown source status",
+			spec:       "done=backlog",
+			wantErr:    true,
+			wantErrMsg: `unknown status "done"`,
+		},
+		{
+			name:       "error - unknown target status",
+			spec:       "backlog=in-progress",
+			wantErr:    true,
+			wantErrMsg: `unknown status "in-progress"`,
+		},
+		{
+			name:       "error - malformed rule",
+			spec:       "backlog>todo",
+			wantErr:    true,
+			wantErrMsg: `rule "backlog>todo" must look like from=to|to`,
+		},
+		{
+			name:       "error - duplicate status",
+			spec:       "backlog=todo;backlog=completed",
+			wantErr:    true,
+			wantErrMsg: `status "backlog" is listed twice`,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, err := ParseTaskWorkflow(tt.spec)
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.want, got)
+			}
+		})
+	}
+}
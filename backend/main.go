@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/hendrihmwn/crud-task-backend/handler"
@@ -9,6 +10,10 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 )
 
@@ -23,10 +28,22 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer client.Disconnect(ctx)
+	// ctx has timed out by the time the server stops
+	defer client.Disconnect(context.Background())
+
+	// cancelled on SIGINT or SIGTERM, which stops the server and the trash purge
+	shutdown, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	r := gin.New()
 
+	// ClientIP feeds the per-address login limits, so X-Forwarded-For is only
+	// honoured from the configured proxies. Gin trusts every proxy by default,
+	// an empty list trusts none and uses the peer address.
+	if err := r.SetTrustedProxies(config.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	r.Use(cors.New(cors.Config{
 		AllowOrigins: []string{"http://localhost:5173", "https://frontend-crud-task-production.up.railway.app"},
 		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
@@ -36,13 +53,32 @@ func main() {
 			"X-Requested-With",
 			"Accept",
 			"Origin",
+			"X-Request-ID",
+			"If-Match",
 		},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "Retry-After", "X-Request-ID", "ETag"},
 		AllowCredentials: true,
 	}))
 
-	handler.InitHandler(r, client, config)
+	taskUseCase := handler.InitHandler(r, client, config)
+	go taskUseCase.RunTrashPurge(shutdown, config.TrashRetention, config.TrashPurgeInterval)
 
-	// Server will listen on 0.0.0.0:8080
-	r.Run()
+	// Server will listen on 0.0.0.0:8080 unless PORT is set
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	srv := &http.Server{Addr: ":" + port, Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-shutdown.Done()
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelStop()
+	if err := srv.Shutdown(stopCtx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
 }
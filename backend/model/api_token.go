@@ -0,0 +1,50 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+
+	// APITokenPrefix tells personal access tokens apart from JWTs in the Authorization header
+	APITokenPrefix = "pat_"
+)
+
+type APITokenBodyParam struct {
+	Name          string   `form:"name" binding:"required,max=100" json:"name"`
+	Scopes        []string `form:"scopes" binding:"required,min=1,dive,oneof=tasks:read tasks:write" json:"scopes"`
+	ExpiresInDays int      `form:"expires_in_days" binding:"omitempty,min=1,max=365" json:"expires_in_days"`
+}
+
+type APITokenGetParam struct {
+	ID string `uri:"id" json:"id"`
+}
+
+type APITokenResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Prefix     string     `json:"prefix"`
+	Token      string     `json:"token,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+// APIToken is a long-lived personal access token. Only the SHA-256 hash is
+// stored, the plain token is shown once when it is created.
+type APIToken struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     string             `bson:"user_id" json:"user_id"`
+	Name       string             `bson:"name" json:"name"`
+	Scopes     []string           `bson:"scopes" json:"scopes"`
+	TokenHash  string             `bson:"token_hash" json:"-"`
+	Prefix     string             `bson:"prefix" json:"prefix"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastUsedAt *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time         `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
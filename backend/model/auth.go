@@ -1,11 +1,73 @@
 package model
 
 type LoginParam struct {
-	Username string `form:"username" binding:"required" json:"username"`
-	Password string `form:"password" binding:"required" json:"password"`
+	Username  string `form:"username" binding:"required" json:"username"`
+	Password  string `form:"password" binding:"required" json:"password"`
+	UserAgent string `form:"-" json:"-"`
+	IP        string `form:"-" json:"-"`
+}
+
+type RegisterParam struct {
+	Username string `form:"username" binding:"required,min=3,max=50" json:"username"`
+	Password string `form:"password" binding:"required,min=8,max=72" json:"password"`
+}
+
+type RefreshTokenParam struct {
+	RefreshToken string `form:"refresh_token" binding:"required" json:"refresh_token"`
+	UserAgent    string `form:"-" json:"-"`
+	IP           string `form:"-" json:"-"`
 }
 
 type AuthResponse struct {
-	Token          string `json:"token"`
-	ExpirationTime int64  `json:"expiration_time"`
+	Token                 string `json:"token"`
+	ExpirationTime        int64  `json:"expiration_time"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshExpirationTime int64  `json:"refresh_expiration_time"`
+}
+
+// Principal is the authenticated caller of a request. Scopes is only set for
+// personal access tokens, a session JWT is not limited by scopes.
+type Principal struct {
+	UserID string
+	Role   string
+	Scopes []string
+}
+
+func (p Principal) HasScope(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManageAll reports whether the caller may act on tasks of other users
+func (p Principal) CanManageAll() bool {
+	return p.Role == RoleAdmin
+}
+
+// CanReadAll reports whether the caller may read tasks of other users. A
+// viewer reads every task but can't change any.
+func (p Principal) CanReadAll() bool {
+	return p.Role == RoleAdmin || p.Role == RoleViewer
+}
+
+// JWK is the public part of a token signing key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
 }
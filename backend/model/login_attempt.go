@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// LoginAttempt counts the consecutive failed logins of one key, either a
+// username or a client address. While LockedUntil is in the future every login
+// for the key is rejected without checking the password.
+type LoginAttempt struct {
+	Key           string     `bson:"_id" json:"key"`
+	Failures      int        `bson:"failures" json:"failures"`
+	LastFailureAt time.Time  `bson:"last_failure_at" json:"last_failure_at"`
+	LockedUntil   *time.Time `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	ExpiresAt     time.Time  `bson:"expires_at" json:"expires_at"`
+}
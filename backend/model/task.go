@@ -1,35 +1,137 @@
 package model
 
 import (
+	"encoding/json"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
 
 type TaskListParam struct {
-	Limit  uint64 `form:"limit" binding:"required" json:"limit"`
-	Page   uint64 `form:"page" binding:"required" json:"page"`
-	Search string `form:"search" json:"search"`
-	Status string `form:"status" json:"status"`
-	SortBy string `form:"sort_by" json:"sort_by"`
-	Order  int    `form:"order" json:"order"`
+	// Limit defaults to the page size of View, a saved view whose filter
+	// and sort apply unless the query sets its own. The params are validated
+	// once the view is applied.
+	Limit uint64 `form:"limit" binding:"required,gte=1,lte=100" json:"limit"`
+	View  string `form:"view" binding:"omitempty,objectid" json:"view"`
+	// Page pages with skip and limit. Without it the list is read with
+	// Cursor, the next_cursor of the previous page or empty for the first one.
+	Page   uint64 `form:"page" binding:"omitempty,gte=1" json:"page"`
+	Cursor string `form:"cursor" binding:"omitempty,excluded_with=Page,max=512" json:"cursor"`
+	// Search is a full-text search over title and description that takes
+	// "phrases" and -negations, SearchMode prefix matches the start of the
+	// title instead
+	Search     string       `form:"search" binding:"max=100" json:"search"`
+	SearchMode SearchMode   `form:"search_mode" binding:"omitempty,oneof=text prefix" json:"search_mode"`
+	Status     TaskStatus   `form:"status" binding:"omitempty,task_status" json:"status"`
+	Priority   TaskPriority `form:"priority" binding:"omitempty,task_priority" json:"priority"`
+	// DueBefore and DueAfter are RFC 3339 times, DueAfter is inclusive
+	DueBefore time.Time `form:"due_before" json:"due_before"`
+	DueAfter  time.Time `form:"due_after" json:"due_after"`
+	// Overdue keeps the open tasks whose due date has passed
+	Overdue bool `form:"overdue" json:"overdue"`
+	// Tags is a comma separated list, TagMatch says whether a task needs any
+	// (the default) or all of them
+	Tags     string   `form:"tags" binding:"max=500" json:"tags"`
+	TagMatch TagMatch `form:"tag_match" binding:"omitempty,oneof=any all" json:"tag_match"`
+	// Blocked keeps the tasks waiting for at least one open task
+	Blocked bool `form:"blocked" json:"blocked"`
+	// Filter is a query like "status:in(backlog,todo) AND created_at>2026-01-01",
+	// parsed by the use case
+	Filter string `form:"filter" binding:"max=1000" json:"filter"`
+	// Sort is a list of sort keys like "-priority,created_at", see
+	// ParseTaskSort. SortBy and Order are the single key form of it.
+	Sort   string `form:"sort" binding:"omitempty,excluded_with=SortBy,max=200,task_sort" json:"sort"`
+	SortBy string `form:"sort_by" binding:"omitempty,oneof=title status priority due_date created_at updated_at completed_at relevance" json:"sort_by"`
+	Order  int    `form:"order" binding:"omitempty,oneof=1 -1" json:"order"`
 }
 
+type SearchMode string
+
+const (
+	SearchModeText   SearchMode = "text"
+	SearchModePrefix SearchMode = "prefix"
+)
+
+// SortByRelevance ranks a full-text search by its score, best match first
+const SortByRelevance = "relevance"
+
 type TaskGetParam struct {
-	ID string `uri:"id" json:"id"`
+	ID string `uri:"id" binding:"required,objectid" json:"id"`
 }
 
+// TaskBodyParam is a whole task, PUT replaces every field with it
 type TaskBodyParam struct {
-	Title       string `form:"title" binding:"required,max=100" json:"title"`
-	Description string `form:"description" binding:"required,max=255" json:"description"`
-	Status      string `form:"status" binding:"required" json:"status"`
+	Title       string       `form:"title" binding:"required,notblank,max=100" json:"title"`
+	Description string       `form:"description" binding:"max=255" json:"description"`
+	Status      TaskStatus   `form:"status" binding:"required,task_status" json:"status"`
+	Priority    TaskPriority `form:"priority" binding:"omitempty,task_priority" json:"priority"`
+	DueDate     *time.Time   `form:"due_date" json:"due_date"`
+	Tags        []string     `form:"tags" binding:"max=20,dive,task_tag" json:"tags"`
+}
+
+// TaskPatchParam is a JSON merge patch (RFC 7396): absent fields are kept and
+// fields sent as null are cleared
+type TaskPatchParam struct {
+	Title       *string       `json:"title" binding:"omitempty,notblank,max=100"`
+	Description *string       `json:"description" binding:"omitempty,max=255"`
+	Status      *TaskStatus   `json:"status" binding:"omitempty,task_status"`
+	Priority    *TaskPriority `json:"priority" binding:"omitempty,task_priority"`
+	DueDate     *time.Time    `json:"due_date"`
+	Tags        *[]string     `json:"tags" binding:"omitempty,max=20,dive,task_tag"`
+	// Null holds the json names of the fields sent as null
+	Null []string `json:"-"`
+}
+
+func (p *TaskPatchParam) UnmarshalJSON(data []byte) error {
+	// decode through an alias so type errors still name the field
+	type patch TaskPatchParam
+	if err := json.Unmarshal(data, (*patch)(p)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	p.Null = nil
+	for name, value := range fields {
+		if string(value) == "null" {
+			p.Null = append(p.Null, name)
+		}
+	}
+	return nil
+}
+
+// IsNull reports whether the patch clears field
+func (p TaskPatchParam) IsNull(field string) bool {
+	for _, name := range p.Null {
+		if name == field {
+			return true
+		}
+	}
+	return false
 }
 
 type TaskResponse struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Status      TaskStatus      `json:"status"`
+	Priority    TaskPriority    `json:"priority,omitempty"`
+	DueDate     *time.Time      `json:"due_date,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Checklist   []ChecklistItem `json:"checklist,omitempty"`
+	Progress    *TaskProgress   `json:"progress,omitempty"` // left out while the task has no checklist
+	BlockedBy   []string        `json:"blocked_by,omitempty"`
+	Version     int64           `json:"version"`
+	CreatedAt   time.Time       `json:"created_at"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+	DeletedBy   string          `json:"deleted_by,omitempty"`
+}
+
+type TaskTrashParam struct {
+	Limit uint64 `form:"limit" binding:"required,gte=1,lte=100" json:"limit"`
+	Page  uint64 `form:"page" binding:"required,gte=1" json:"page"`
 }
 
 type TaskMeta struct {
@@ -38,11 +140,29 @@ type TaskMeta struct {
 	Total int `json:"total"`
 }
 
+// TaskCursorMeta describes a page read with a cursor, NextCursor is left out
+// on the last page
+type TaskCursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
 type Task struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Title       string             `bson:"title" json:"title"`
-	Description string             `bson:"description,omitempty" json:"description,omitempty"`
-	Status      string             `bson:"status" json:"status"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OwnerID      string             `bson:"owner_id" json:"owner_id"`
+	Title        string             `bson:"title" json:"title"`
+	Description  string             `bson:"description,omitempty" json:"description,omitempty"`
+	Status       TaskStatus         `bson:"status" json:"status"`
+	Priority     TaskPriority       `bson:"priority,omitempty" json:"priority,omitempty"`
+	PriorityRank int                `bson:"priority_rank,omitempty" json:"-"` // Priority.Rank(), what sorting on priority uses
+	DueDate      *time.Time         `bson:"due_date,omitempty" json:"due_date,omitempty"`
+	CompletedAt  *time.Time         `bson:"completed_at,omitempty" json:"completed_at,omitempty"` // set while the status is completed
+	Tags         []string           `bson:"tags,omitempty" json:"tags,omitempty"`                 // normalized, see NormalizeTags
+	Checklist    []ChecklistItem    `bson:"checklist,omitempty" json:"checklist,omitempty"`
+	BlockedBy    []string           `bson:"blocked_by,omitempty" json:"blocked_by,omitempty"` // ids of the tasks this one waits for
+	Version      int64              `bson:"version" json:"version"`                           // bumped on every update, backs the ETag
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
+	DeletedAt    *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"` // set while the task is in the trash
+	DeletedBy    string             `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
 }
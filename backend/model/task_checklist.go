@@ -0,0 +1,41 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// MaxChecklistItems is how many checklist items a task can hold
+const MaxChecklistItems = 100
+
+// ChecklistItem is one step of a task. Items are kept in the order of the
+// checklist array, the reorder endpoint rewrites it.
+type ChecklistItem struct {
+	ID   primitive.ObjectID `bson:"_id" json:"id"`
+	Text string             `bson:"text" json:"text"`
+	Done bool               `bson:"done" json:"done"`
+}
+
+// TaskProgress counts the done checklist items of a task
+type TaskProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+type ChecklistItemParam struct {
+	TaskID string `uri:"id" binding:"required,objectid" json:"task_id"`
+	ItemID string `uri:"item_id" binding:"required,objectid" json:"item_id"`
+}
+
+type ChecklistItemBodyParam struct {
+	Text string `form:"text" binding:"required,notblank,max=200" json:"text"`
+	Done bool   `form:"done" json:"done"`
+}
+
+// ChecklistItemPatchParam changes the fields it carries, the others are kept
+type ChecklistItemPatchParam struct {
+	Text *string `json:"text" binding:"omitempty,notblank,max=200"`
+	Done *bool   `json:"done"`
+}
+
+// ChecklistOrderParam lists every item id of the checklist in the new order
+type ChecklistOrderParam struct {
+	IDs []string `form:"ids" binding:"required,dive,objectid" json:"ids"`
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// TaskCursor is the position after the last task of a page: its value for
+// each sort key and its _id, which breaks ties. It travels as an opaque
+// base64 string.
+type TaskCursor struct {
+	Sort   string             `json:"s"`
+	Values []any              `json:"v,omitempty"`
+	ID     primitive.ObjectID `json:"id"`
+}
+
+// NewTaskCursor is the cursor that continues after t in sort
+func NewTaskCursor(t *Task, sort []SortKey) TaskCursor {
+	cursor := TaskCursor{Sort: FormatTaskSort(sort), ID: t.ID}
+	for _, key := range sort {
+		cursor.Values = append(cursor.Values, key.value(t))
+	}
+	return cursor
+}
+
+func (c TaskCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTaskCursor reads a cursor that Encode made for sort, ok is false for
+// anything else. The values come back with the type their fields have in the
+// database, so they compare like the stored fields.
+func DecodeTaskCursor(s string, sort []SortKey) (c TaskCursor, ok bool) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, false
+	}
+	var raw struct {
+		Sort   string             `json:"s"`
+		Values []json.RawMessage  `json:"v"`
+		ID     primitive.ObjectID `json:"id"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil || raw.ID.IsZero() {
+		return c, false
+	}
+	if raw.Sort != FormatTaskSort(sort) || len(raw.Values) != len(sort) {
+		return c, false
+	}
+
+	c = TaskCursor{Sort: raw.Sort, ID: raw.ID}
+	for i, key := range sort {
+		value, ok := decodeSortValue(key, raw.Values[i])
+		if !ok {
+			return c, false
+		}
+		c.Values = append(c.Values, value)
+	}
+	return c, true
+}
+
+func decodeSortValue(key SortKey, data json.RawMessage) (any, bool) {
+	if string(data) == "null" {
+		return nil, true
+	}
+	switch key.Field {
+	case "title", "status":
+		var v string
+		err := json.Unmarshal(data, &v)
+		return v, err == nil
+	case "priority":
+		var v int
+		err := json.Unmarshal(data, &v)
+		return v, err == nil
+	case "due_date", "created_at", "updated_at", "completed_at":
+		var v time.Time
+		err := json.Unmarshal(data, &v)
+		return v, err == nil
+	}
+	return nil, false
+}
@@ -0,0 +1,14 @@
+package model
+
+// MaxTaskBlockers is how many tasks can block a single task
+const MaxTaskBlockers = 50
+
+type TaskDependencyParam struct {
+	TaskID    string `uri:"id" binding:"required,objectid" json:"task_id"`
+	BlockerID string `uri:"blocker_id" binding:"required,objectid" json:"blocker_id"`
+}
+
+// TaskDependencyBodyParam names the task that blocks the one in the path
+type TaskDependencyBodyParam struct {
+	BlockedBy string `form:"blocked_by" binding:"required,objectid" json:"blocked_by"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type TaskEventAction string
+
+const (
+	TaskEventCreated  TaskEventAction = "created"
+	TaskEventUpdated  TaskEventAction = "updated"
+	TaskEventDeleted  TaskEventAction = "deleted"
+	TaskEventRestored TaskEventAction = "restored"
+)
+
+type TaskHistoryParam struct {
+	Limit uint64 `form:"limit" binding:"required,gte=1,lte=100" json:"limit"`
+	Page  uint64 `form:"page" binding:"required,gte=1" json:"page"`
+}
+
+// TaskEvent is one entry of the audit trail of a task
+type TaskEvent struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TaskID  string             `bson:"task_id" json:"task_id"`
+	OwnerID string             `bson:"owner_id" json:"-"`
+	Action  TaskEventAction    `bson:"action" json:"action"`
+	ActorID string             `bson:"actor_id" json:"actor_id"`
+	// Changes lists the fields the event changed, with nil standing for a
+	// field that didn't exist yet
+	Changes   []FieldChange `bson:"changes,omitempty" json:"changes,omitempty"`
+	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+}
+
+type FieldChange struct {
+	Field  string `bson:"field" json:"field"`
+	Before any    `bson:"before" json:"before"`
+	After  any    `bson:"after" json:"after"`
+}
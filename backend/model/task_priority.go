@@ -0,0 +1,33 @@
+package model
+
+import "slices"
+
+type TaskPriority string
+
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+// TaskPriorities lists every valid priority from the lowest to the highest
+var TaskPriorities = []TaskPriority{
+	TaskPriorityLow,
+	TaskPriorityMedium,
+	TaskPriorityHigh,
+	TaskPriorityUrgent,
+}
+
+// DefaultTaskPriority is given to tasks saved without a priority
+const DefaultTaskPriority = TaskPriorityMedium
+
+func (p TaskPriority) Valid() bool {
+	return slices.Contains(TaskPriorities, p)
+}
+
+// Rank orders priorities from 1 for low up, 0 is no priority. Tasks store it
+// next to the priority to sort on.
+func (p TaskPriority) Rank() int {
+	return slices.Index(TaskPriorities, p) + 1
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// SortKey is one key of a task sort, Field is its name in the API and Order
+// 1 or -1
+type SortKey struct {
+	Field string
+	Order int
+}
+
+// taskSortFields whitelists the fields a task list can be sorted on, with the
+// document field each one sorts by. Priority sorts by its rank, so urgent
+// comes after high rather than alphabetically.
+var taskSortFields = map[string]string{
+	"title":         "title",
+	"status":        "status",
+	"priority":      "priority_rank",
+	"due_date":      "due_date",
+	"created_at":    "created_at",
+	"updated_at":    "updated_at",
+	"completed_at":  "completed_at",
+	SortByRelevance: "",
+}
+
+// TaskSortFields lists the sortable fields in a stable order, for messages
+func TaskSortFields() []string {
+	fields := make([]string, 0, len(taskSortFields))
+	for field := range taskSortFields {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// ParseTaskSort reads a sort like "-priority,created_at": sortable fields,
+// each at most once and descending when prefixed with -. ok is false for
+// anything else.
+func ParseTaskSort(s string) (keys []SortKey, ok bool) {
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		key := SortKey{Field: part, Order: 1}
+		if strings.HasPrefix(part, "-") {
+			key = SortKey{Field: part[1:], Order: -1}
+		}
+		if _, known := taskSortFields[key.Field]; !known {
+			return nil, false
+		}
+		if slices.ContainsFunc(keys, func(k SortKey) bool { return k.Field == key.Field }) {
+			return nil, false
+		}
+		keys = append(keys, key)
+	}
+	return keys, true
+}
+
+// FormatTaskSort is the inverse of ParseTaskSort
+func FormatTaskSort(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Field
+		if key.Order == -1 {
+			parts[i] = "-" + key.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Column is the document field the key sorts by, empty for relevance. A
+// field outside the whitelist, which only the use cases can ask for, sorts by
+// the document field of that name.
+func (k SortKey) Column() string {
+	if column, ok := taskSortFields[k.Field]; ok {
+		return column
+	}
+	return k.Field
+}
+
+// value is what t holds in the sorted field, nil when it is not set
+func (k SortKey) value(t *Task) any {
+	switch k.Field {
+	case "title":
+		return t.Title
+	case "status":
+		return string(t.Status)
+	case "priority":
+		if t.PriorityRank == 0 {
+			return nil
+		}
+		return t.PriorityRank
+	case "due_date":
+		return timeOrNil(t.DueDate)
+	case "created_at":
+		return t.CreatedAt
+	case "updated_at":
+		return t.UpdatedAt
+	case "completed_at":
+		return timeOrNil(t.CompletedAt)
+	}
+	return nil
+}
+
+func timeOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
@@ -0,0 +1,54 @@
+package model
+
+import "slices"
+
+type TaskStatus string
+
+const (
+	TaskStatusBacklog    TaskStatus = "backlog"
+	TaskStatusTodo       TaskStatus = "todo"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusCompleted  TaskStatus = "completed"
+	TaskStatusCancelled  TaskStatus = "cancelled"
+)
+
+// TaskStatuses lists every valid status in workflow order
+var TaskStatuses = []TaskStatus{
+	TaskStatusBacklog,
+	TaskStatusTodo,
+	TaskStatusInProgress,
+	TaskStatusCompleted,
+	TaskStatusCancelled,
+}
+
+// ClosedTaskStatuses are the statuses of tasks nobody works on anymore, they
+// can't be overdue
+var ClosedTaskStatuses = []TaskStatus{TaskStatusCompleted, TaskStatusCancelled}
+
+func (s TaskStatus) Valid() bool {
+	return slices.Contains(TaskStatuses, s)
+}
+
+// TaskWorkflow maps a status to the statuses a task may move to from it
+type TaskWorkflow map[TaskStatus][]TaskStatus
+
+// DefaultTaskWorkflow lets open tasks move freely. Completed and cancelled
+// tasks have to be reopened (back to in_progress or backlog) first.
+func DefaultTaskWorkflow() TaskWorkflow {
+	return TaskWorkflow{
+		TaskStatusBacklog:    {TaskStatusTodo, TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled},
+		TaskStatusTodo:       {TaskStatusBacklog, TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled},
+		TaskStatusInProgress: {TaskStatusBacklog, TaskStatusTodo, TaskStatusCompleted, TaskStatusCancelled},
+		TaskStatusCompleted:  {TaskStatusInProgress},
+		TaskStatusCancelled:  {TaskStatusBacklog},
+	}
+}
+
+// Allows reports whether a task may move from one status to another. Staying
+// put is always allowed, and so is leaving a status that is no longer valid.
+func (w TaskWorkflow) Allows(from, to TaskStatus) bool {
+	if from == to || !from.Valid() {
+		return true
+	}
+	return slices.Contains(w[from], to)
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxTaskTags is how many tags a task can carry
+const MaxTaskTags = 20
+
+// a tag is a lowercase word of letters, digits, '-', '_' or '.'
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,29}$`)
+
+// NormalizeTag is the stored form of a tag, tags are matched case-insensitively
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// ValidTag reports whether tag is a valid tag once normalized
+func ValidTag(tag string) bool {
+	return tagPattern.MatchString(NormalizeTag(tag))
+}
+
+// NormalizeTags normalizes every tag and drops the blank and repeated ones,
+// keeping the order they were given in
+func NormalizeTags(tags []string) []string {
+	var res []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+	return res
+}
+
+// TagMatch says whether a task needs any or all of the tags of a list filter
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+// TagCount is a tag with the number of tasks that carry it
+type TagCount struct {
+	Name  string `bson:"_id" json:"name"`
+	Count int64  `bson:"count" json:"count"`
+}
+
+type TagParam struct {
+	Name string `uri:"tag" binding:"required,task_tag" json:"name"`
+}
+
+type TagRenameParam struct {
+	Name string `form:"name" binding:"required,task_tag" json:"name"`
+}
+
+type TagRenameResponse struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Updated int64  `json:"updated"`
+}
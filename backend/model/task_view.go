@@ -0,0 +1,33 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// TaskView is a saved filter, sort and page size for the task list, applied
+// with GET /tasks?view=<id>. A shared view can be read and applied by every
+// user, over their own tasks, but only its owner changes it.
+type TaskView struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OwnerID   string             `bson:"owner_id" json:"owner_id"`
+	Name      string             `bson:"name" json:"name"`
+	Filter    string             `bson:"filter,omitempty" json:"filter"` // see TaskListParam.Filter
+	Sort      string             `bson:"sort,omitempty" json:"sort"`     // see ParseTaskSort
+	PageSize  uint64             `bson:"page_size" json:"page_size"`
+	Shared    bool               `bson:"shared" json:"shared"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type TaskViewGetParam struct {
+	ID string `uri:"id" binding:"required,objectid" json:"id"`
+}
+
+type TaskViewBodyParam struct {
+	Name     string `form:"name" binding:"required,notblank,max=100" json:"name"`
+	Filter   string `form:"filter" binding:"max=1000" json:"filter"`
+	Sort     string `form:"sort" binding:"omitempty,max=200,task_sort" json:"sort"`
+	PageSize uint64 `form:"page_size" binding:"required,gte=1,lte=100" json:"page_size"`
+	Shared   bool   `form:"shared" json:"shared"`
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"sync"
+	"time"
+)
+
+// how often expired counters are swept so the map doesn't grow forever
+const sweepInterval = time.Minute
+
+type LoginAttemptRepository struct {
+	mu        sync.Mutex
+	attempts  map[string]model.LoginAttempt
+	nextSweep time.Time
+}
+
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{attempts: make(map[string]model.LoginAttempt)}
+}
+
+func (r *LoginAttemptRepository) Get(ctx context.Context, key string) (res *model.LoginAttempt, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	if time.Now().After(attempt.ExpiresAt) {
+		delete(r.attempts, key)
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (res *model.LoginAttempt, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(at)
+
+	attempt, ok := r.attempts[key]
+	if !ok || attempt.LastFailureAt.Before(at.Add(-window)) {
+		attempt = model.LoginAttempt{Key: key}
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = at
+	if expiresAt := at.Add(window); expiresAt.After(attempt.ExpiresAt) {
+		attempt.ExpiresAt = expiresAt
+	}
+	r.attempts[key] = attempt
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = model.LoginAttempt{Key: key}
+	}
+	attempt.LockedUntil = &until
+	if until.After(attempt.ExpiresAt) {
+		attempt.ExpiresAt = until
+	}
+	r.attempts[key] = attempt
+	return nil
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+// sweep must be called with the lock held
+func (r *LoginAttemptRepository) sweep(now time.Time) {
+	if now.Before(r.nextSweep) {
+		return
+	}
+	for key, attempt := range r.attempts {
+		if now.After(attempt.ExpiresAt) {
+			delete(r.attempts, key)
+		}
+	}
+	r.nextSweep = now.Add(sweepInterval)
+}
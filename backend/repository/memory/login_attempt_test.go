@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type LoginAttemptRepositoryTestSuite struct {
+	suite.Suite
+	Repository *LoginAttemptRepository
+}
+
+func TestLoginAttemptRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LoginAttemptRepositoryTestSuite))
+}
+
+func (s *LoginAttemptRepositoryTestSuite) SetupTest() {
+	s.Repository = NewLoginAttemptRepository()
+}
+
+func (s *LoginAttemptRepositoryTestSuite) TestRecordFailure() {
+	ctx := context.TODO()
+	now := time.Now()
+
+	res, err := s.Repository.Get(ctx, "user:admin")
+	s.NoError(err)
+	s.Nil(res)
+
+	for i := 1; i <= 3; i++ {
+		res, err = s.Repository.RecordFailure(ctx, "user:admin", now, time.Minute)
+		s.NoError(err)
+		s.Equal(i, res.Failures)
+	}
+
+	// a failure after a quiet window starts over
+	res, err = s.Repository.RecordFailure(ctx, "user:admin", now.Add(2*time.Minute), time.Minute)
+	s.NoError(err)
+	s.Equal(1, res.Failures)
+
+	// other keys are counted separately
+	res, err = s.Repository.RecordFailure(ctx, "ip:127.0.0.1", now, time.Minute)
+	s.NoError(err)
+	s.Equal(1, res.Failures)
+}
+
+func (s *LoginAttemptRepositoryTestSuite) TestLock() {
+	ctx := context.TODO()
+	now := time.Now()
+	until := now.Add(time.Hour)
+
+	_, err := s.Repository.RecordFailure(ctx, "user:admin", now, time.Minute)
+	s.NoError(err)
+	s.NoError(s.Repository.Lock(ctx, "user:admin", until))
+
+	res, err := s.Repository.Get(ctx, "user:admin")
+	s.NoError(err)
+	s.Equal(1, res.Failures)
+	s.Equal(until, *res.LockedUntil)
+	// the counter lives at least as long as the lock
+	s.Equal(until, res.ExpiresAt)
+
+	s.NoError(s.Repository.Reset(ctx, "user:admin"))
+	res, err = s.Repository.Get(ctx, "user:admin")
+	s.NoError(err)
+	s.Nil(res)
+}
+
+func (s *LoginAttemptRepositoryTestSuite) TestExpiry() {
+	ctx := context.TODO()
+	past := time.Now().Add(-time.Hour)
+
+	_, err := s.Repository.RecordFailure(ctx, "user:admin", past, time.Minute)
+	s.NoError(err)
+
+	res, err := s.Repository.Get(ctx, "user:admin")
+	s.NoError(err)
+	s.Nil(res)
+}
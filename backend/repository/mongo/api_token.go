@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type APITokenRepository struct {
+	coll *mongo.Collection
+}
+
+func NewAPITokenRepository(client *mongo.Client, dbName, collName string) *APITokenRepository {
+	coll := client.Database(dbName).Collection(collName)
+	_ = ensureAPITokenIndexes(context.Background(), coll)
+	return &APITokenRepository{coll: coll}
+}
+
+func ensureAPITokenIndexes(ctx context.Context, coll *mongo.Collection) error {
+	indexes := coll.Indexes()
+	models := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetName("idx_api_tokens_hash").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_api_tokens_user_createdAt_desc"),
+		},
+	}
+	_, err := indexes.CreateMany(ctx, models)
+	return err
+}
+
+func (r *APITokenRepository) Create(ctx context.Context, req *model.APIToken) (res *model.APIToken, err error) {
+	if req == nil {
+		return nil, errors.New("api token is nil")
+	}
+	req.CreatedAt = time.Now().UTC()
+	if req.ID.IsZero() {
+		req.ID = primitive.NewObjectID()
+	}
+	_, err = r.coll.InsertOne(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ListByUser returns the tokens of a user that were not revoked, newest first
+func (r *APITokenRepository) ListByUser(ctx context.Context, userID string) ([]*model.APIToken, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cur, err := r.coll.Find(ctx, bson.M{"user_id": userID, "revoked_at": nil}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var result []*model.APIToken
+	for cur.Next(ctx) {
+		var t model.APIToken
+		if err := cur.Decode(&t); err != nil {
+			return nil, err
+		}
+		result = append(result, &t)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *APITokenRepository) GetByHash(ctx context.Context, hash string) (res *model.APIToken, err error) {
+	var t model.APIToken
+	if err := r.coll.FindOne(ctx, bson.M{"token_hash": hash}).Decode(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *APITokenRepository) Revoke(ctx context.Context, id, userID string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": oid, "user_id": userID, "revoked_at": nil}
+	res, err := r.coll.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now().UTC()}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *APITokenRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": at}})
+	return err
+}
@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoginAttemptRepository struct {
+	coll *mongo.Collection
+}
+
+func NewLoginAttemptRepository(client *mongo.Client, dbName, collName string) *LoginAttemptRepository {
+	coll := client.Database(dbName).Collection(collName)
+	_ = ensureLoginAttemptIndexes(context.Background(), coll)
+	return &LoginAttemptRepository{coll: coll}
+}
+
+func ensureLoginAttemptIndexes(ctx context.Context, coll *mongo.Collection) error {
+	indexes := coll.Indexes()
+	models := []mongo.IndexModel{
+		{
+			// counters are keyed by _id, so only the cleanup needs an index
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("idx_login_attempts_ttl").SetExpireAfterSeconds(0),
+		},
+	}
+	_, err := indexes.CreateMany(ctx, models)
+	return err
+}
+
+func (r *LoginAttemptRepository) Get(ctx context.Context, key string) (res *model.LoginAttempt, err error) {
+	var attempt model.LoginAttempt
+	if err := r.coll.FindOne(ctx, bson.M{"_id": key}).Decode(&attempt); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// the TTL monitor only runs once a minute
+	if time.Now().After(attempt.ExpiresAt) {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+// RecordFailure increments the counter in a single upsert so concurrent
+// failures on different replicas are all counted.
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (res *model.LoginAttempt, err error) {
+	at = at.UTC()
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"failures": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{"$last_failure_at", at.Add(-window)}},
+				bson.M{"$add": bson.A{"$failures", 1}},
+				1,
+			}},
+			"last_failure_at": at,
+			"expires_at":      bson.M{"$max": bson.A{"$expires_at", at.Add(window)}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var attempt model.LoginAttempt
+	if err := r.coll.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&attempt); err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	until = until.UTC()
+	update := bson.M{
+		"$set": bson.M{"locked_until": until},
+		"$max": bson.M{"expires_at": until},
+	}
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": key}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	_, err := r.coll.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RefreshTokenRepository struct {
+	coll *mongo.Collection
+}
+
+func NewRefreshTokenRepository(client *mongo.Client, dbName, collName string) *RefreshTokenRepository {
+	coll := client.Database(dbName).Collection(collName)
+	_ = ensureRefreshTokenIndexes(context.Background(), coll)
+	return &RefreshTokenRepository{coll: coll}
+}
+
+func ensureRefreshTokenIndexes(ctx context.Context, coll *mongo.Collection) error {
+	indexes := coll.Indexes()
+	models := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetName("idx_refresh_tokens_hash").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "family_id", Value: 1}},
+			Options: options.Index().SetName("idx_refresh_tokens_family"),
+		},
+		{
+			// let mongo drop expired tokens by itself
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("idx_refresh_tokens_ttl").SetExpireAfterSeconds(0),
+		},
+	}
+	_, err := indexes.CreateMany(ctx, models)
+	return err
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, req *model.RefreshToken) (res *model.RefreshToken, err error) {
+	if req == nil {
+		return nil, errors.New("refresh token is nil")
+	}
+	req.CreatedAt = time.Now().UTC()
+	if req.ID.IsZero() {
+		req.ID = primitive.NewObjectID()
+	}
+	_, err = r.coll.InsertOne(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, hash string) (res *model.RefreshToken, err error) {
+	var t model.RefreshToken
+	if err := r.coll.FindOne(ctx, bson.M{"token_hash": hash}).Decode(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// MarkUsed flags the token as consumed. It only succeeds once per token, so a
+// concurrent second use gets mongo.ErrNoDocuments and can be treated as reuse.
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "used_at": nil, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"used_at": time.Now().UTC()}}
+	res, err := r.coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	filter := bson.M{"family_id": familyID, "revoked_at": nil}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now().UTC()}}
+	_, err := r.coll.UpdateMany(ctx, filter, update)
+	return err
+}
@@ -38,11 +38,72 @@ func ensureIndexes(ctx context.Context, coll *mongo.Collection) error {
 			Keys:    bson.D{{Key: "title", Value: 1}},
 			Options: options.Index().SetName("idx_tasks_title"),
 		},
+		{
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_tasks_owner_createdAt_desc"),
+		},
+		{
+			// backs the search, a title match weighs ten times a description match
+			Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+			Options: options.Index().SetName("idx_tasks_text").
+				SetWeights(bson.M{"title": 10, "description": 1}),
+		},
+		{
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "due_date", Value: 1}},
+			Options: options.Index().SetName("idx_tasks_owner_dueDate"),
+		},
+		{
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "priority", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_tasks_owner_priority_createdAt_desc"),
+		},
+		{
+			// sort=-priority,created_at, and read backwards its opposite
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "priority_rank", Value: -1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("idx_tasks_owner_priorityRank_createdAt"),
+		},
+		{
+			// multikey, one entry per tag
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "tags", Value: 1}},
+			Options: options.Index().SetName("idx_tasks_owner_tags"),
+		},
+		{
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "blocked_by", Value: 1}},
+			Options: options.Index().SetName("idx_tasks_owner_blockedBy"),
+		},
+		{
+			Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+			Options: options.Index().SetName("idx_tasks_deleted_at"),
+		},
 	}
 	_, err := indexes.CreateMany(ctx, models)
 	return err
 }
 
+// textScore is the relevance of a task to the full-text search
+var textScore = bson.M{"$meta": "textScore"}
+
+// trashed matches the tasks in the trash, a nil deleted_at matches the others
+var trashed = bson.M{"$ne": nil}
+
+// ownedFilter matches a single task that is not in the trash, restricted to
+// ownerID unless it is empty
+func ownedFilter(oid primitive.ObjectID, ownerID string) bson.M {
+	filter := bson.M{"_id": oid, "deleted_at": nil}
+	if ownerID != "" {
+		filter["owner_id"] = ownerID
+	}
+	return filter
+}
+
+// versionFilter is ownedFilter that also requires version, unless it is 0
+func versionFilter(oid primitive.ObjectID, ownerID string, version int64) bson.M {
+	filter := ownedFilter(oid, ownerID)
+	if version != 0 {
+		filter["version"] = version
+	}
+	return filter
+}
+
 func (r *TaskRepository) Create(ctx context.Context, req *model.Task) (res *model.Task, err error) {
 	now := time.Now().UTC()
 	if req == nil {
@@ -50,6 +111,7 @@ func (r *TaskRepository) Create(ctx context.Context, req *model.Task) (res *mode
 	}
 	req.CreatedAt = now
 	req.UpdatedAt = now
+	req.Version = 1
 	if req.ID.IsZero() {
 		req.ID = primitive.NewObjectID()
 	}
@@ -60,34 +122,110 @@ func (r *TaskRepository) Create(ctx context.Context, req *model.Task) (res *mode
 	return req, nil
 }
 
-func (r *TaskRepository) GetByID(ctx context.Context, id string) (req *model.Task, err error) {
+func (r *TaskRepository) GetByID(ctx context.Context, id, ownerID string) (req *model.Task, err error) {
 	var t model.Task
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return &t, err
 	}
-	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&t); err != nil {
+	if err := r.coll.FindOne(ctx, ownedFilter(oid, ownerID)).Decode(&t); err != nil {
 		return &t, err
 	}
 	return &t, nil
 }
 
-func (r *TaskRepository) List(ctx context.Context, filter bson.M, page, limit int64, sortField string, sortOrder int, searchText string) ([]*model.Task, int64, error) {
-	if filter == nil {
-		filter = bson.M{}
+// ListByIDs loads the tasks with the given hex ids, the ones in the trash
+// included. Ids that are malformed or match nothing are skipped.
+func (r *TaskRepository) ListByIDs(ctx context.Context, ids []string, ownerID string) ([]*model.Task, error) {
+	oids := objectIDs(ids)
+	if len(oids) == 0 {
+		return nil, nil
+	}
+	filter := bson.M{"_id": bson.M{"$in": oids}}
+	if ownerID != "" {
+		filter["owner_id"] = ownerID
 	}
 
-	// apply simple text search (case-insensitive) across common fields
-	if searchText != "" {
-		search := bson.M{"$or": []bson.M{
-			{"title": bson.M{"$regex": searchText, "$options": "i"}},
-		}}
-		if len(filter) == 0 {
-			filter = search
-		} else {
-			filter = bson.M{"$and": []bson.M{filter, search}}
+	cur, err := r.coll.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var res []*model.Task
+	if err := cur.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BumpVersions gives each of tasks a new version if it is still at the
+// version it has, and returns how many were
+func (r *TaskRepository) BumpVersions(ctx context.Context, tasks []*model.Task) (int64, error) {
+	if len(tasks) == 0 {
+		return 0, nil
+	}
+	current := make([]bson.M, 0, len(tasks))
+	for _, t := range tasks {
+		current = append(current, bson.M{"_id": t.ID, "version": t.Version})
+	}
+	res, err := r.coll.UpdateMany(ctx, bson.M{"$or": current}, bson.M{"$inc": bson.M{"version": 1}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// OpenBlockerIDs lists the tasks that block another task and are still open:
+// not in the trash and not in one of model.ClosedTaskStatuses
+func (r *TaskRepository) OpenBlockerIDs(ctx context.Context, ownerID string) ([]string, error) {
+	filter := bson.M{"deleted_at": nil, "blocked_by.0": bson.M{"$exists": true}}
+	if ownerID != "" {
+		filter["owner_id"] = ownerID
+	}
+	blockers, err := r.coll.Distinct(ctx, "blocked_by", filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(blockers))
+	for _, id := range blockers {
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
 		}
 	}
+	oids := objectIDs(ids)
+	if len(oids) == 0 {
+		return nil, nil
+	}
+
+	open, err := r.coll.Distinct(ctx, "_id", bson.M{
+		"_id":        bson.M{"$in": oids},
+		"deleted_at": nil,
+		"status":     bson.M{"$nin": model.ClosedTaskStatuses},
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(open))
+	for _, id := range open {
+		if oid, ok := id.(primitive.ObjectID); ok {
+			res = append(res, oid.Hex())
+		}
+	}
+	return res, nil
+}
+
+func objectIDs(ids []string) []primitive.ObjectID {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+	return oids
+}
+
+// List leaves out the tasks in the trash unless filter has its own deleted_at
+func (r *TaskRepository) List(ctx context.Context, filter bson.M, page, limit int64, sort []model.SortKey, searchText string) ([]*model.Task, int64, error) {
+	filter = listFilter(filter, searchText)
 
 	// total matching documents (ignores pagination)
 	total, err := r.coll.CountDocuments(ctx, filter)
@@ -107,13 +245,7 @@ func (r *TaskRepository) List(ctx context.Context, filter bson.M, page, limit in
 		findOpts.Skip = &skip
 	}
 
-	// apply sorting if provided (default order = 1 if invalid)
-	if sortField != "" {
-		if sortOrder != 1 && sortOrder != -1 {
-			sortOrder = 1
-		}
-		findOpts.Sort = bson.D{{Key: sortField, Value: sortOrder}}
-	}
+	findOpts.Sort, findOpts.Projection = sortDoc(sort)
 
 	cur, err := r.coll.Find(ctx, filter, &findOpts)
 	if err != nil {
@@ -135,41 +267,296 @@ func (r *TaskRepository) List(ctx context.Context, filter bson.M, page, limit in
 	return result, total, nil
 }
 
-func (r *TaskRepository) Update(ctx context.Context, id string, data bson.M) (res *model.Task, err error) {
+// ListAfter returns up to limit tasks that come after cursor in sort, from
+// the first task when cursor is nil. sort can't hold relevance, as the text
+// score can't be compared in a filter.
+func (r *TaskRepository) ListAfter(ctx context.Context, filter bson.M, limit int64, sort []model.SortKey, searchText string, cursor *model.TaskCursor) ([]*model.Task, error) {
+	filter = listFilter(filter, searchText)
+	if cursor != nil {
+		filter = bson.M{"$and": []bson.M{filter, afterFilter(sort, cursor)}}
+	}
+
+	sortD, _ := sortDoc(sort)
+	cur, err := r.coll.Find(ctx, filter, options.Find().SetSort(sortD).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.Task
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sortDoc is the Mongo sort for keys, with a projection of the text score
+// when it sorts by relevance. _id always comes last, in the direction of the
+// last key, so the order is total and stable between pages.
+func sortDoc(keys []model.SortKey) (sort bson.D, projection any) {
+	order := 1
+	for _, key := range keys {
+		if key.Field == model.SortByRelevance {
+			// best match first, whatever the order
+			sort = append(sort, bson.E{Key: "score", Value: textScore})
+			projection = bson.M{"score": textScore}
+			continue
+		}
+		order = key.Order
+		if order != -1 {
+			order = 1
+		}
+		sort = append(sort, bson.E{Key: key.Column(), Value: order})
+	}
+	return append(sort, bson.E{Key: "_id", Value: order}), projection
+}
+
+// afterFilter matches the tasks that sortDoc(keys) puts after cursor: a task
+// comes after when it ties with cursor on the first keys and is past it on
+// the next one, or ties on every key and is past it on _id. Missing fields
+// sort before any value.
+func afterFilter(keys []model.SortKey, cursor *model.TaskCursor) bson.M {
+	var after []bson.M
+	ties := bson.M{}
+	order := 1
+	for i, key := range keys {
+		order = key.Order
+		if order != -1 {
+			order = 1
+		}
+		column, value := key.Column(), cursor.Values[i]
+
+		for _, past := range pastValue(column, value, order) {
+			after = append(after, merge(ties, past))
+		}
+		ties[column] = value
+	}
+	op := "$gt"
+	if order == -1 {
+		op = "$lt"
+	}
+	after = append(after, merge(ties, bson.M{"_id": bson.M{op: cursor.ID}}))
+	return bson.M{"$or": after}
+}
+
+// pastValue matches the values of column that come after value in order. A
+// nil value, a missing field, comes before everything else.
+func pastValue(column string, value any, order int) []bson.M {
+	switch {
+	case value == nil && order == 1:
+		return []bson.M{{column: bson.M{"$ne": nil}}}
+	case value == nil:
+		return nil
+	case order == 1:
+		return []bson.M{{column: bson.M{"$gt": value}}}
+	default:
+		return []bson.M{{column: bson.M{"$lt": value}}, {column: nil}}
+	}
+}
+
+func merge(a, b bson.M) bson.M {
+	res := make(bson.M, len(a)+len(b))
+	for k, v := range a {
+		res[k] = v
+	}
+	for k, v := range b {
+		res[k] = v
+	}
+	return res
+}
+
+// listFilter leaves the trash out of filter unless it has its own deleted_at,
+// and adds the full-text search
+func listFilter(filter bson.M, searchText string) bson.M {
+	if filter == nil {
+		filter = bson.M{}
+	}
+	if _, ok := filter["deleted_at"]; !ok {
+		filter["deleted_at"] = nil
+	}
+
+	// full-text search over the text index, which handles "phrases" and
+	// -negations itself
+	if searchText != "" {
+		search := bson.M{"$text": bson.M{"$search": searchText}}
+		filter = bson.M{"$and": []bson.M{filter, search}}
+	}
+	return filter
+}
+
+func (r *TaskRepository) Update(ctx context.Context, id, ownerID string, version int64, data bson.M) (res *model.Task, err error) {
 	var updated model.Task
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
 
-	// prevent updating the document ID
+	// prevent updating the document ID or moving it to another owner, and the
+	// version is only ever bumped here
 	delete(data, "_id")
+	delete(data, "owner_id")
+	delete(data, "version")
 
 	data["updated_at"] = time.Now().UTC()
-	updateDoc := bson.D{{Key: "$set", Value: data}}
+	updateDoc := bson.D{
+		{Key: "$set", Value: data},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	}
 
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
-	if err := r.coll.FindOneAndUpdate(ctx, bson.M{"_id": oid}, updateDoc, opts).Decode(&updated); err != nil {
+	if err := r.coll.FindOneAndUpdate(ctx, versionFilter(oid, ownerID, version), updateDoc, opts).Decode(&updated); err != nil {
 		return &updated, err
 	}
 	return &updated, nil
 }
 
-func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+// Delete moves the task to the trash
+func (r *TaskRepository) Delete(ctx context.Context, id, ownerID string, version int64, deletedBy string) error {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
-	res, err := r.coll.DeleteOne(ctx, bson.M{"_id": oid})
+	now := time.Now().UTC()
+	res, err := r.coll.UpdateOne(ctx, versionFilter(oid, ownerID, version), bson.D{
+		{Key: "$set", Value: bson.M{"deleted_at": now, "deleted_by": deletedBy, "updated_at": now}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	})
 	if err != nil {
 		return err
 	}
-	if res.DeletedCount == 0 {
-		return errors.New("data not found")
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
 	}
 	return nil
 }
 
+// Restore takes the task out of the trash
+func (r *TaskRepository) Restore(ctx context.Context, id, ownerID string) (res *model.Task, err error) {
+	var restored model.Task
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": oid, "deleted_at": trashed}
+	if ownerID != "" {
+		filter["owner_id"] = ownerID
+	}
+	updateDoc := bson.D{
+		{Key: "$set", Value: bson.M{"updated_at": time.Now().UTC()}},
+		{Key: "$unset", Value: bson.M{"deleted_at": "", "deleted_by": ""}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.coll.FindOneAndUpdate(ctx, filter, updateDoc, opts).Decode(&restored); err != nil {
+		return nil, err
+	}
+	return &restored, nil
+}
+
+// Purge removes the tasks that went to the trash before the given time for
+// good. It deletes them one at a time so it returns exactly the ids it
+// removed, also when it fails part way.
+func (r *TaskRepository) Purge(ctx context.Context, before time.Time) ([]string, error) {
+	var purged []string
+	opts := options.FindOneAndDelete().SetProjection(bson.M{"_id": 1})
+	for {
+		var t model.Task
+		err := r.coll.FindOneAndDelete(ctx, bson.M{"deleted_at": bson.M{"$lte": before}}, opts).Decode(&t)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return purged, nil
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged = append(purged, t.ID.Hex())
+	}
+}
+
+// RemoveBlockers takes ids out of the blocked_by of every task, the tasks
+// that change get a new version
+func (r *TaskRepository) RemoveBlockers(ctx context.Context, ids []string) error {
+	_, err := r.coll.UpdateMany(ctx, bson.M{"blocked_by": bson.M{"$in": ids}}, bson.D{
+		{Key: "$pull", Value: bson.M{"blocked_by": bson.M{"$in": ids}}},
+		{Key: "$set", Value: bson.M{"updated_at": time.Now().UTC()}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	})
+	return err
+}
+
+// Tags counts the tasks carrying each tag, most used first. Tasks in the trash
+// are not counted.
+func (r *TaskRepository) Tags(ctx context.Context, ownerID string) ([]model.TagCount, error) {
+	match := bson.M{"deleted_at": nil, "tags.0": bson.M{"$exists": true}}
+	if ownerID != "" {
+		match["owner_id"] = ownerID
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cur, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	res := []model.TagCount{}
+	if err := cur.All(ctx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RenameTag replaces from with to on every task carrying it, trashed tasks
+// included so a restore doesn't bring the old name back. A task that already
+// has to keeps a single copy. It returns the renamed tasks as they were
+// before, so their changes can be recorded, also when it fails part way.
+func (r *TaskRepository) RenameTag(ctx context.Context, ownerID, from, to string) ([]*model.Task, error) {
+	filter := bson.M{"tags": from}
+	if ownerID != "" {
+		filter["owner_id"] = ownerID
+	}
+
+	// a pipeline update so each task is renamed atomically: map from to to,
+	// then drop the duplicates keeping the first position
+	renamed := bson.M{"$map": bson.M{
+		"input": "$tags",
+		"in":    bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$$this", from}}, to, "$$this"}},
+	}}
+	deduped := bson.M{"$reduce": bson.M{
+		"input":        renamed,
+		"initialValue": bson.A{},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{"$$this", "$$value"}},
+			"$$value",
+			bson.M{"$concatArrays": bson.A{"$$value", bson.A{"$$this"}}},
+		}},
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"tags":       deduped,
+			"updated_at": time.Now().UTC(),
+			"version":    bson.M{"$add": bson.A{"$version", 1}},
+		}}},
+	}
+
+	// one task at a time, a renamed task no longer matches the filter
+	var before []*model.Task
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	for {
+		var t model.Task
+		err := r.coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&t)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return before, nil
+		}
+		if err != nil {
+			return before, err
+		}
+		before = append(before, &t)
+	}
+}
+
 func (r *TaskRepository) Count(ctx context.Context, filter bson.M) (int64, error) {
 	if filter == nil {
 		filter = bson.M{}
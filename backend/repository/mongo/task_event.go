@@ -0,0 +1,96 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TaskEventRepository struct {
+	coll *mongo.Collection
+}
+
+func NewTaskEventRepository(client *mongo.Client, dbName, collName string) *TaskEventRepository {
+	coll := client.Database(dbName).Collection(collName)
+	_ = ensureTaskEventIndexes(context.Background(), coll)
+	return &TaskEventRepository{coll: coll}
+}
+
+func ensureTaskEventIndexes(ctx context.Context, coll *mongo.Collection) error {
+	indexes := coll.Indexes()
+	models := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_task_events_task_createdAt_desc"),
+		},
+	}
+	_, err := indexes.CreateMany(ctx, models)
+	return err
+}
+
+func (r *TaskEventRepository) Create(ctx context.Context, req *model.TaskEvent) error {
+	if req == nil {
+		return errors.New("task event is nil")
+	}
+	if req.CreatedAt.IsZero() {
+		req.CreatedAt = time.Now().UTC()
+	}
+	if req.ID.IsZero() {
+		req.ID = primitive.NewObjectID()
+	}
+	_, err := r.coll.InsertOne(ctx, req)
+	return err
+}
+
+// ListByTask returns the events of a task, newest first. ownerID restricts
+// them to that owner unless it is empty.
+func (r *TaskEventRepository) ListByTask(ctx context.Context, taskID, ownerID string, page, limit int64) ([]*model.TaskEvent, int64, error) {
+	filter := bson.M{"task_id": taskID}
+	if ownerID != "" {
+		filter["owner_id"] = ownerID
+	}
+
+	total, err := r.coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	// _id breaks ties between events written in the same millisecond
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cur, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cur.Close(ctx)
+
+	var result []*model.TaskEvent
+	for cur.Next(ctx) {
+		var e model.TaskEvent
+		if err := cur.Decode(&e); err != nil {
+			return nil, 0, err
+		}
+		result = append(result, &e)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
+
+// DeleteByTasks removes the events of the given tasks
+func (r *TaskEventRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	_, err := r.coll.DeleteMany(ctx, bson.M{"task_id": bson.M{"$in": taskIDs}})
+	return err
+}
@@ -0,0 +1,118 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TaskViewRepository struct {
+	coll *mongo.Collection
+}
+
+func NewTaskViewRepository(client *mongo.Client, dbName, collName string) *TaskViewRepository {
+	coll := client.Database(dbName).Collection(collName)
+	_ = ensureTaskViewIndexes(context.Background(), coll)
+	return &TaskViewRepository{coll: coll}
+}
+
+func ensureTaskViewIndexes(ctx context.Context, coll *mongo.Collection) error {
+	indexes := coll.Indexes()
+	models := []mongo.IndexModel{
+		{
+			// a user can't have two views with the same name
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("idx_task_views_owner_name").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "shared", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("idx_task_views_shared_name"),
+		},
+	}
+	_, err := indexes.CreateMany(ctx, models)
+	return err
+}
+
+func (r *TaskViewRepository) Create(ctx context.Context, req *model.TaskView) (res *model.TaskView, err error) {
+	if req == nil {
+		return nil, errors.New("task view is nil")
+	}
+	now := time.Now().UTC()
+	req.CreatedAt = now
+	req.UpdatedAt = now
+	if req.ID.IsZero() {
+		req.ID = primitive.NewObjectID()
+	}
+	_, err = r.coll.InsertOne(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (r *TaskViewRepository) GetByID(ctx context.Context, id string) (res *model.TaskView, err error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	var v model.TaskView
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// List returns the views of ownerID and the shared ones by name, every view
+// when ownerID is empty
+func (r *TaskViewRepository) List(ctx context.Context, ownerID string) ([]*model.TaskView, error) {
+	filter := bson.M{}
+	if ownerID != "" {
+		filter["$or"] = []bson.M{{"owner_id": ownerID}, {"shared": true}}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cur, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.TaskView
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *TaskViewRepository) Update(ctx context.Context, id string, data bson.M) (res *model.TaskView, err error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	data["updated_at"] = time.Now().UTC()
+
+	var updated model.TaskView
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	if err := r.coll.FindOneAndUpdate(ctx, bson.M{"_id": oid}, bson.M{"$set": data}, opts).Decode(&updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *TaskViewRepository) Delete(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	res, err := r.coll.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
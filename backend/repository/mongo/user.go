@@ -0,0 +1,69 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UserRepository struct {
+	coll *mongo.Collection
+}
+
+func NewUserRepository(client *mongo.Client, dbName, collName string) *UserRepository {
+	coll := client.Database(dbName).Collection(collName)
+	// usernames must be unique -- ignore errors here
+	_ = ensureUserIndexes(context.Background(), coll)
+	return &UserRepository{coll: coll}
+}
+
+func ensureUserIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().SetName("idx_users_username").SetUnique(true),
+	})
+	return err
+}
+
+func (r *UserRepository) Create(ctx context.Context, req *model.User) (res *model.User, err error) {
+	now := time.Now().UTC()
+	if req == nil {
+		return nil, errors.New("user is nil")
+	}
+	req.CreatedAt = now
+	req.UpdatedAt = now
+	if req.ID.IsZero() {
+		req.ID = primitive.NewObjectID()
+	}
+	_, err = r.coll.InsertOne(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (res *model.User, err error) {
+	var u model.User
+	if err := r.coll.FindOne(ctx, bson.M{"username": username}).Decode(&u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (res *model.User, err error) {
+	var u model.User
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
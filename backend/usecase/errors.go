@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Kind classifies a domain error, the handlers map each kind to one HTTP
+// status without having to know every error the use cases return.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalid
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindPreconditionFailed
+	KindTooManyRequests
+)
+
+// Error is a domain error. The sentinels below are compared with errors.Is,
+// anything that is not an *Error is treated as internal. Code is the machine
+// readable counterpart of Message that ends up in the response.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+}
+
+func NewError(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors by code, so a sentinel still matches after Errorf
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Errorf returns a copy of e with a more specific message
+func (e *Error) Errorf(format string, args ...any) *Error {
+	return &Error{Kind: e.Kind, Code: e.Code, Message: fmt.Sprintf(format, args...)}
+}
+
+var (
+	ErrNotFound      = NewError(KindNotFound, "not_found", "data not found")
+	ErrNoUpdateData  = NewError(KindInvalid, "no_update_data", "no update data provided")
+	ErrFieldRequired = NewError(KindInvalid, "field_required", "a required field can't be cleared")
+	ErrTagNotFound   = NewError(KindNotFound, "tag_not_found", "no task has this tag")
+	ErrInvalidCursor = NewError(KindInvalid, "invalid_cursor", "cursor is malformed or was made for another sort")
+	ErrInvalidFilter = NewError(KindInvalid, "invalid_filter", "filter is not valid")
+
+	ErrRelevanceNeedsSearch = NewError(KindInvalid, "relevance_needs_search", "sorting by relevance needs a full-text search")
+	ErrRelevanceNeedsPage   = NewError(KindInvalid, "relevance_needs_page", "sorting by relevance can only be read by page")
+
+	ErrChecklistItemNotFound = NewError(KindNotFound, "checklist_item_not_found", "checklist item not found")
+	ErrChecklistFull         = NewError(KindInvalid, "checklist_full", "a task can't have more than 100 checklist items")
+	ErrInvalidChecklistOrder = NewError(KindInvalid, "invalid_checklist_order", "ids must list every checklist item once")
+
+	ErrDependencyNotFound = NewError(KindNotFound, "dependency_not_found", "task is not blocked by this task")
+	ErrTooManyBlockers    = NewError(KindInvalid, "too_many_blockers", "a task can't be blocked by more than 50 tasks")
+	ErrDependencyCycle    = NewError(KindConflict, "dependency_cycle", "dependency would create a cycle")
+	ErrTaskBlocked        = NewError(KindConflict, "task_blocked", "task is blocked by open tasks")
+
+	ErrViewNameTaken = NewError(KindConflict, "view_name_taken", "you already have a view with this name")
+	ErrViewNotOwner  = NewError(KindForbidden, "view_not_owner", "only the owner can change a view")
+
+	ErrInvalidStatusTransition = NewError(KindConflict, "invalid_status_transition", "status transition is not allowed")
+	ErrVersionMismatch         = NewError(KindPreconditionFailed, "version_mismatch", "task was changed since it was read")
+	ErrConcurrentUpdate        = NewError(KindConflict, "concurrent_update", "task was changed by another request, try again")
+
+	ErrInvalidCredentials  = NewError(KindUnauthorized, "invalid_credentials", "invalid username or password")
+	ErrUsernameTaken       = NewError(KindConflict, "username_taken", "username already taken")
+	ErrPasswordTooLong     = NewError(KindInvalid, "password_too_long", "password must be at most 72 bytes")
+	ErrInvalidRefreshToken = NewError(KindUnauthorized, "invalid_refresh_token", "invalid refresh token")
+	ErrRefreshTokenReused  = NewError(KindUnauthorized, "refresh_token_reused", "refresh token reuse detected")
+	ErrTooManyLogins       = NewError(KindTooManyRequests, "too_many_login_attempts", "too many failed login attempts, try again later")
+	ErrInvalidAPIToken     = NewError(KindUnauthorized, "invalid_api_token", "invalid api token")
+)
+
+// LoginLockedError is returned by Login while the username or the client
+// address is locked out. It matches ErrTooManyLogins with errors.Is.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrTooManyLogins.Error()
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrTooManyLogins
+}
+
+// KindOf returns the kind of the first domain error in the chain of err
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// notFound turns the repository errors for a missing document into
+// ErrNotFound. A malformed id can't match any document either.
+func notFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+		return ErrNotFound
+	}
+	return err
+}
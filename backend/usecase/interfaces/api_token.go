@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+//go:generate mockery --name=APITokenMongoRepository --keeptree --output=mocks --case=underscore --with-expecter=true
+type APITokenMongoRepository interface {
+	Create(ctx context.Context, req *model.APIToken) (res *model.APIToken, err error)
+	ListByUser(ctx context.Context, userID string) ([]*model.APIToken, error)
+	GetByHash(ctx context.Context, hash string) (res *model.APIToken, err error)
+	Revoke(ctx context.Context, id, userID string) error
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error
+}
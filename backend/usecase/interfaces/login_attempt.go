@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"time"
+)
+
+// LoginAttemptRepository keeps the failed login counters. The in-memory
+// implementation is enough for a single instance, the Mongo one shares the
+// counters between replicas.
+//
+//go:generate mockery --name=LoginAttemptRepository --keeptree --output=mocks --case=underscore --with-expecter=true
+type LoginAttemptRepository interface {
+	// Get returns nil when nothing is recorded for the key
+	Get(ctx context.Context, key string) (res *model.LoginAttempt, err error)
+	// RecordFailure counts one more failure, starting over when the previous
+	// one is older than window, and returns the updated counter
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (res *model.LoginAttempt, err error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
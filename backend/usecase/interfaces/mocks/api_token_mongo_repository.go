@@ -0,0 +1,314 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APITokenMongoRepository is an autogenerated mock type for the APITokenMongoRepository type
+type APITokenMongoRepository struct {
+	mock.Mock
+}
+
+type APITokenMongoRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APITokenMongoRepository) EXPECT() *APITokenMongoRepository_Expecter {
+	return &APITokenMongoRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *APITokenMongoRepository) Create(ctx context.Context, req *model.APIToken) (*model.APIToken, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.APIToken) (*model.APIToken, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.APIToken) *model.APIToken); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.APIToken) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APITokenMongoRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type APITokenMongoRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *model.APIToken
+func (_e *APITokenMongoRepository_Expecter) Create(ctx interface{}, req interface{}) *APITokenMongoRepository_Create_Call {
+	return &APITokenMongoRepository_Create_Call{Call: _e.mock.On("Create", ctx, req)}
+}
+
+func (_c *APITokenMongoRepository_Create_Call) Run(run func(ctx context.Context, req *model.APIToken)) *APITokenMongoRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.APIToken))
+	})
+	return _c
+}
+
+func (_c *APITokenMongoRepository_Create_Call) Return(res *model.APIToken, err error) *APITokenMongoRepository_Create_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *APITokenMongoRepository_Create_Call) RunAndReturn(run func(context.Context, *model.APIToken) (*model.APIToken, error)) *APITokenMongoRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByHash provides a mock function with given fields: ctx, hash
+func (_m *APITokenMongoRepository) GetByHash(ctx context.Context, hash string) (*model.APIToken, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByHash")
+	}
+
+	var r0 *model.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.APIToken, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.APIToken); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APITokenMongoRepository_GetByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByHash'
+type APITokenMongoRepository_GetByHash_Call struct {
+	*mock.Call
+}
+
+// GetByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *APITokenMongoRepository_Expecter) GetByHash(ctx interface{}, hash interface{}) *APITokenMongoRepository_GetByHash_Call {
+	return &APITokenMongoRepository_GetByHash_Call{Call: _e.mock.On("GetByHash", ctx, hash)}
+}
+
+func (_c *APITokenMongoRepository_GetByHash_Call) Run(run func(ctx context.Context, hash string)) *APITokenMongoRepository_GetByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APITokenMongoRepository_GetByHash_Call) Return(res *model.APIToken, err error) *APITokenMongoRepository_GetByHash_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *APITokenMongoRepository_GetByHash_Call) RunAndReturn(run func(context.Context, string) (*model.APIToken, error)) *APITokenMongoRepository_GetByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *APITokenMongoRepository) ListByUser(ctx context.Context, userID string) ([]*model.APIToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []*model.APIToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.APIToken, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.APIToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.APIToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APITokenMongoRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type APITokenMongoRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *APITokenMongoRepository_Expecter) ListByUser(ctx interface{}, userID interface{}) *APITokenMongoRepository_ListByUser_Call {
+	return &APITokenMongoRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *APITokenMongoRepository_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *APITokenMongoRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APITokenMongoRepository_ListByUser_Call) Return(_a0 []*model.APIToken, _a1 error) *APITokenMongoRepository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APITokenMongoRepository_ListByUser_Call) RunAndReturn(run func(context.Context, string) ([]*model.APIToken, error)) *APITokenMongoRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, id, userID
+func (_m *APITokenMongoRepository) Revoke(ctx context.Context, id string, userID string) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APITokenMongoRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type APITokenMongoRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userID string
+func (_e *APITokenMongoRepository_Expecter) Revoke(ctx interface{}, id interface{}, userID interface{}) *APITokenMongoRepository_Revoke_Call {
+	return &APITokenMongoRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, id, userID)}
+}
+
+func (_c *APITokenMongoRepository_Revoke_Call) Run(run func(ctx context.Context, id string, userID string)) *APITokenMongoRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *APITokenMongoRepository_Revoke_Call) Return(_a0 error) *APITokenMongoRepository_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APITokenMongoRepository_Revoke_Call) RunAndReturn(run func(context.Context, string, string) error) *APITokenMongoRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TouchLastUsed provides a mock function with given fields: ctx, id, at
+func (_m *APITokenMongoRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TouchLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APITokenMongoRepository_TouchLastUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TouchLastUsed'
+type APITokenMongoRepository_TouchLastUsed_Call struct {
+	*mock.Call
+}
+
+// TouchLastUsed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+//   - at time.Time
+func (_e *APITokenMongoRepository_Expecter) TouchLastUsed(ctx interface{}, id interface{}, at interface{}) *APITokenMongoRepository_TouchLastUsed_Call {
+	return &APITokenMongoRepository_TouchLastUsed_Call{Call: _e.mock.On("TouchLastUsed", ctx, id, at)}
+}
+
+func (_c *APITokenMongoRepository_TouchLastUsed_Call) Run(run func(ctx context.Context, id primitive.ObjectID, at time.Time)) *APITokenMongoRepository_TouchLastUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *APITokenMongoRepository_TouchLastUsed_Call) Return(_a0 error) *APITokenMongoRepository_TouchLastUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APITokenMongoRepository_TouchLastUsed_Call) RunAndReturn(run func(context.Context, primitive.ObjectID, time.Time) error) *APITokenMongoRepository_TouchLastUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPITokenMongoRepository creates a new instance of APITokenMongoRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPITokenMongoRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APITokenMongoRepository {
+	mock := &APITokenMongoRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
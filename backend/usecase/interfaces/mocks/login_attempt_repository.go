@@ -0,0 +1,254 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+)
+
+// LoginAttemptRepository is an autogenerated mock type for the LoginAttemptRepository type
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+type LoginAttemptRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoginAttemptRepository) EXPECT() *LoginAttemptRepository_Expecter {
+	return &LoginAttemptRepository_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *LoginAttemptRepository) Get(ctx context.Context, key string) (*model.LoginAttempt, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *model.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.LoginAttempt, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.LoginAttempt); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAttemptRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type LoginAttemptRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *LoginAttemptRepository_Expecter) Get(ctx interface{}, key interface{}) *LoginAttemptRepository_Get_Call {
+	return &LoginAttemptRepository_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *LoginAttemptRepository_Get_Call) Run(run func(ctx context.Context, key string)) *LoginAttemptRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Get_Call) Return(res *model.LoginAttempt, err error) *LoginAttemptRepository_Get_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Get_Call) RunAndReturn(run func(context.Context, string) (*model.LoginAttempt, error)) *LoginAttemptRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function with given fields: ctx, key, until
+func (_m *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	ret := _m.Called(ctx, key, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, key, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginAttemptRepository_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type LoginAttemptRepository_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - until time.Time
+func (_e *LoginAttemptRepository_Expecter) Lock(ctx interface{}, key interface{}, until interface{}) *LoginAttemptRepository_Lock_Call {
+	return &LoginAttemptRepository_Lock_Call{Call: _e.mock.On("Lock", ctx, key, until)}
+}
+
+func (_c *LoginAttemptRepository_Lock_Call) Run(run func(ctx context.Context, key string, until time.Time)) *LoginAttemptRepository_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Lock_Call) Return(_a0 error) *LoginAttemptRepository_Lock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Lock_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *LoginAttemptRepository_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFailure provides a mock function with given fields: ctx, key, at, window
+func (_m *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*model.LoginAttempt, error) {
+	ret := _m.Called(ctx, key, at, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailure")
+	}
+
+	var r0 *model.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) (*model.LoginAttempt, error)); ok {
+		return rf(ctx, key, at, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) *model.LoginAttempt); ok {
+		r0 = rf(ctx, key, at, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, key, at, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginAttemptRepository_RecordFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFailure'
+type LoginAttemptRepository_RecordFailure_Call struct {
+	*mock.Call
+}
+
+// RecordFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - at time.Time
+//   - window time.Duration
+func (_e *LoginAttemptRepository_Expecter) RecordFailure(ctx interface{}, key interface{}, at interface{}, window interface{}) *LoginAttemptRepository_RecordFailure_Call {
+	return &LoginAttemptRepository_RecordFailure_Call{Call: _e.mock.On("RecordFailure", ctx, key, at, window)}
+}
+
+func (_c *LoginAttemptRepository_RecordFailure_Call) Run(run func(ctx context.Context, key string, at time.Time, window time.Duration)) *LoginAttemptRepository_RecordFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_RecordFailure_Call) Return(res *model.LoginAttempt, err error) *LoginAttemptRepository_RecordFailure_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_RecordFailure_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Duration) (*model.LoginAttempt, error)) *LoginAttemptRepository_RecordFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function with given fields: ctx, key
+func (_m *LoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginAttemptRepository_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type LoginAttemptRepository_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *LoginAttemptRepository_Expecter) Reset(ctx interface{}, key interface{}) *LoginAttemptRepository_Reset_Call {
+	return &LoginAttemptRepository_Reset_Call{Call: _e.mock.On("Reset", ctx, key)}
+}
+
+func (_c *LoginAttemptRepository_Reset_Call) Run(run func(ctx context.Context, key string)) *LoginAttemptRepository_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Reset_Call) Return(_a0 error) *LoginAttemptRepository_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginAttemptRepository_Reset_Call) RunAndReturn(run func(context.Context, string) error) *LoginAttemptRepository_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginAttemptRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginAttemptRepository {
+	mock := &LoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
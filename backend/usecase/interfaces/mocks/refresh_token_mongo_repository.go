@@ -0,0 +1,252 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshTokenMongoRepository is an autogenerated mock type for the RefreshTokenMongoRepository type
+type RefreshTokenMongoRepository struct {
+	mock.Mock
+}
+
+type RefreshTokenMongoRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RefreshTokenMongoRepository) EXPECT() *RefreshTokenMongoRepository_Expecter {
+	return &RefreshTokenMongoRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *RefreshTokenMongoRepository) Create(ctx context.Context, req *model.RefreshToken) (*model.RefreshToken, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RefreshToken) (*model.RefreshToken, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RefreshToken) *model.RefreshToken); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.RefreshToken) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokenMongoRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type RefreshTokenMongoRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *model.RefreshToken
+func (_e *RefreshTokenMongoRepository_Expecter) Create(ctx interface{}, req interface{}) *RefreshTokenMongoRepository_Create_Call {
+	return &RefreshTokenMongoRepository_Create_Call{Call: _e.mock.On("Create", ctx, req)}
+}
+
+func (_c *RefreshTokenMongoRepository_Create_Call) Run(run func(ctx context.Context, req *model.RefreshToken)) *RefreshTokenMongoRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.RefreshToken))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_Create_Call) Return(res *model.RefreshToken, err error) *RefreshTokenMongoRepository_Create_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_Create_Call) RunAndReturn(run func(context.Context, *model.RefreshToken) (*model.RefreshToken, error)) *RefreshTokenMongoRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByHash provides a mock function with given fields: ctx, hash
+func (_m *RefreshTokenMongoRepository) GetByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByHash")
+	}
+
+	var r0 *model.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.RefreshToken, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.RefreshToken); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RefreshToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTokenMongoRepository_GetByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByHash'
+type RefreshTokenMongoRepository_GetByHash_Call struct {
+	*mock.Call
+}
+
+// GetByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *RefreshTokenMongoRepository_Expecter) GetByHash(ctx interface{}, hash interface{}) *RefreshTokenMongoRepository_GetByHash_Call {
+	return &RefreshTokenMongoRepository_GetByHash_Call{Call: _e.mock.On("GetByHash", ctx, hash)}
+}
+
+func (_c *RefreshTokenMongoRepository_GetByHash_Call) Run(run func(ctx context.Context, hash string)) *RefreshTokenMongoRepository_GetByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_GetByHash_Call) Return(res *model.RefreshToken, err error) *RefreshTokenMongoRepository_GetByHash_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_GetByHash_Call) RunAndReturn(run func(context.Context, string) (*model.RefreshToken, error)) *RefreshTokenMongoRepository_GetByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkUsed provides a mock function with given fields: ctx, id
+func (_m *RefreshTokenMongoRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenMongoRepository_MarkUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkUsed'
+type RefreshTokenMongoRepository_MarkUsed_Call struct {
+	*mock.Call
+}
+
+// MarkUsed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id primitive.ObjectID
+func (_e *RefreshTokenMongoRepository_Expecter) MarkUsed(ctx interface{}, id interface{}) *RefreshTokenMongoRepository_MarkUsed_Call {
+	return &RefreshTokenMongoRepository_MarkUsed_Call{Call: _e.mock.On("MarkUsed", ctx, id)}
+}
+
+func (_c *RefreshTokenMongoRepository_MarkUsed_Call) Run(run func(ctx context.Context, id primitive.ObjectID)) *RefreshTokenMongoRepository_MarkUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.ObjectID))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_MarkUsed_Call) Return(_a0 error) *RefreshTokenMongoRepository_MarkUsed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_MarkUsed_Call) RunAndReturn(run func(context.Context, primitive.ObjectID) error) *RefreshTokenMongoRepository_MarkUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeFamily provides a mock function with given fields: ctx, familyID
+func (_m *RefreshTokenMongoRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	ret := _m.Called(ctx, familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshTokenMongoRepository_RevokeFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeFamily'
+type RefreshTokenMongoRepository_RevokeFamily_Call struct {
+	*mock.Call
+}
+
+// RevokeFamily is a helper method to define mock.On call
+//   - ctx context.Context
+//   - familyID string
+func (_e *RefreshTokenMongoRepository_Expecter) RevokeFamily(ctx interface{}, familyID interface{}) *RefreshTokenMongoRepository_RevokeFamily_Call {
+	return &RefreshTokenMongoRepository_RevokeFamily_Call{Call: _e.mock.On("RevokeFamily", ctx, familyID)}
+}
+
+func (_c *RefreshTokenMongoRepository_RevokeFamily_Call) Run(run func(ctx context.Context, familyID string)) *RefreshTokenMongoRepository_RevokeFamily_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_RevokeFamily_Call) Return(_a0 error) *RefreshTokenMongoRepository_RevokeFamily_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RefreshTokenMongoRepository_RevokeFamily_Call) RunAndReturn(run func(context.Context, string) error) *RefreshTokenMongoRepository_RevokeFamily_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRefreshTokenMongoRepository creates a new instance of RefreshTokenMongoRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRefreshTokenMongoRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RefreshTokenMongoRepository {
+	mock := &RefreshTokenMongoRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,201 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+)
+
+// TaskEventRepository is an autogenerated mock type for the TaskEventRepository type
+type TaskEventRepository struct {
+	mock.Mock
+}
+
+type TaskEventRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TaskEventRepository) EXPECT() *TaskEventRepository_Expecter {
+	return &TaskEventRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *TaskEventRepository) Create(ctx context.Context, req *model.TaskEvent) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.TaskEvent) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskEventRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type TaskEventRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *model.TaskEvent
+func (_e *TaskEventRepository_Expecter) Create(ctx interface{}, req interface{}) *TaskEventRepository_Create_Call {
+	return &TaskEventRepository_Create_Call{Call: _e.mock.On("Create", ctx, req)}
+}
+
+func (_c *TaskEventRepository_Create_Call) Run(run func(ctx context.Context, req *model.TaskEvent)) *TaskEventRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.TaskEvent))
+	})
+	return _c
+}
+
+func (_c *TaskEventRepository_Create_Call) Return(_a0 error) *TaskEventRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskEventRepository_Create_Call) RunAndReturn(run func(context.Context, *model.TaskEvent) error) *TaskEventRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteByTasks provides a mock function with given fields: ctx, taskIDs
+func (_m *TaskEventRepository) DeleteByTasks(ctx context.Context, taskIDs []string) error {
+	ret := _m.Called(ctx, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, taskIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskEventRepository_DeleteByTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByTasks'
+type TaskEventRepository_DeleteByTasks_Call struct {
+	*mock.Call
+}
+
+// DeleteByTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskIDs []string
+func (_e *TaskEventRepository_Expecter) DeleteByTasks(ctx interface{}, taskIDs interface{}) *TaskEventRepository_DeleteByTasks_Call {
+	return &TaskEventRepository_DeleteByTasks_Call{Call: _e.mock.On("DeleteByTasks", ctx, taskIDs)}
+}
+
+func (_c *TaskEventRepository_DeleteByTasks_Call) Run(run func(ctx context.Context, taskIDs []string)) *TaskEventRepository_DeleteByTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *TaskEventRepository_DeleteByTasks_Call) Return(_a0 error) *TaskEventRepository_DeleteByTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskEventRepository_DeleteByTasks_Call) RunAndReturn(run func(context.Context, []string) error) *TaskEventRepository_DeleteByTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByTask provides a mock function with given fields: ctx, taskID, ownerID, page, limit
+func (_m *TaskEventRepository) ListByTask(ctx context.Context, taskID string, ownerID string, page int64, limit int64) ([]*model.TaskEvent, int64, error) {
+	ret := _m.Called(ctx, taskID, ownerID, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByTask")
+	}
+
+	var r0 []*model.TaskEvent
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, int64) ([]*model.TaskEvent, int64, error)); ok {
+		return rf(ctx, taskID, ownerID, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, int64) []*model.TaskEvent); ok {
+		r0 = rf(ctx, taskID, ownerID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TaskEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, int64) int64); ok {
+		r1 = rf(ctx, taskID, ownerID, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64, int64) error); ok {
+		r2 = rf(ctx, taskID, ownerID, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// TaskEventRepository_ListByTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByTask'
+type TaskEventRepository_ListByTask_Call struct {
+	*mock.Call
+}
+
+// ListByTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID string
+//   - ownerID string
+//   - page int64
+//   - limit int64
+func (_e *TaskEventRepository_Expecter) ListByTask(ctx interface{}, taskID interface{}, ownerID interface{}, page interface{}, limit interface{}) *TaskEventRepository_ListByTask_Call {
+	return &TaskEventRepository_ListByTask_Call{Call: _e.mock.On("ListByTask", ctx, taskID, ownerID, page, limit)}
+}
+
+func (_c *TaskEventRepository_ListByTask_Call) Run(run func(ctx context.Context, taskID string, ownerID string, page int64, limit int64)) *TaskEventRepository_ListByTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(int64))
+	})
+	return _c
+}
+
+func (_c *TaskEventRepository_ListByTask_Call) Return(_a0 []*model.TaskEvent, _a1 int64, _a2 error) *TaskEventRepository_ListByTask_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *TaskEventRepository_ListByTask_Call) RunAndReturn(run func(context.Context, string, string, int64, int64) ([]*model.TaskEvent, int64, error)) *TaskEventRepository_ListByTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTaskEventRepository creates a new instance of TaskEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskEventRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskEventRepository {
+	mock := &TaskEventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -25,6 +26,63 @@ func (_m *TaskMongoRepository) EXPECT() *TaskMongoRepository_Expecter {
 	return &TaskMongoRepository_Expecter{mock: &_m.Mock}
 }
 
+// BumpVersions provides a mock function with given fields: ctx, tasks
+func (_m *TaskMongoRepository) BumpVersions(ctx context.Context, tasks []*model.Task) (int64, error) {
+	ret := _m.Called(ctx, tasks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BumpVersions")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.Task) (int64, error)); ok {
+		return rf(ctx, tasks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.Task) int64); ok {
+		r0 = rf(ctx, tasks)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*model.Task) error); ok {
+		r1 = rf(ctx, tasks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_BumpVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BumpVersions'
+type TaskMongoRepository_BumpVersions_Call struct {
+	*mock.Call
+}
+
+// BumpVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tasks []*model.Task
+func (_e *TaskMongoRepository_Expecter) BumpVersions(ctx interface{}, tasks interface{}) *TaskMongoRepository_BumpVersions_Call {
+	return &TaskMongoRepository_BumpVersions_Call{Call: _e.mock.On("BumpVersions", ctx, tasks)}
+}
+
+func (_c *TaskMongoRepository_BumpVersions_Call) Run(run func(ctx context.Context, tasks []*model.Task)) *TaskMongoRepository_BumpVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*model.Task))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_BumpVersions_Call) Return(_a0 int64, _a1 error) *TaskMongoRepository_BumpVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskMongoRepository_BumpVersions_Call) RunAndReturn(run func(context.Context, []*model.Task) (int64, error)) *TaskMongoRepository_BumpVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, req
 func (_m *TaskMongoRepository) Create(ctx context.Context, req *model.Task) (*model.Task, error) {
 	ret := _m.Called(ctx, req)
@@ -84,17 +142,17 @@ func (_c *TaskMongoRepository_Create_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *TaskMongoRepository) Delete(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// Delete provides a mock function with given fields: ctx, id, ownerID, version, deletedBy
+func (_m *TaskMongoRepository) Delete(ctx context.Context, id string, ownerID string, version int64, deletedBy string) error {
+	ret := _m.Called(ctx, id, ownerID, version, deletedBy)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, string) error); ok {
+		r0 = rf(ctx, id, ownerID, version, deletedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,13 +168,16 @@ type TaskMongoRepository_Delete_Call struct {
 // Delete is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *TaskMongoRepository_Expecter) Delete(ctx interface{}, id interface{}) *TaskMongoRepository_Delete_Call {
-	return &TaskMongoRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+//   - ownerID string
+//   - version int64
+//   - deletedBy string
+func (_e *TaskMongoRepository_Expecter) Delete(ctx interface{}, id interface{}, ownerID interface{}, version interface{}, deletedBy interface{}) *TaskMongoRepository_Delete_Call {
+	return &TaskMongoRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, ownerID, version, deletedBy)}
 }
 
-func (_c *TaskMongoRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *TaskMongoRepository_Delete_Call {
+func (_c *TaskMongoRepository_Delete_Call) Run(run func(ctx context.Context, id string, ownerID string, version int64, deletedBy string)) *TaskMongoRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(string))
 	})
 	return _c
 }
@@ -126,14 +187,14 @@ func (_c *TaskMongoRepository_Delete_Call) Return(_a0 error) *TaskMongoRepositor
 	return _c
 }
 
-func (_c *TaskMongoRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *TaskMongoRepository_Delete_Call {
+func (_c *TaskMongoRepository_Delete_Call) RunAndReturn(run func(context.Context, string, string, int64, string) error) *TaskMongoRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function with given fields: ctx, id
-func (_m *TaskMongoRepository) GetByID(ctx context.Context, id string) (*model.Task, error) {
-	ret := _m.Called(ctx, id)
+// GetByID provides a mock function with given fields: ctx, id, ownerID
+func (_m *TaskMongoRepository) GetByID(ctx context.Context, id string, ownerID string) (*model.Task, error) {
+	ret := _m.Called(ctx, id, ownerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByID")
@@ -141,19 +202,19 @@ func (_m *TaskMongoRepository) GetByID(ctx context.Context, id string) (*model.T
 
 	var r0 *model.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Task, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Task, error)); ok {
+		return rf(ctx, id, ownerID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Task); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Task); ok {
+		r0 = rf(ctx, id, ownerID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -169,13 +230,14 @@ type TaskMongoRepository_GetByID_Call struct {
 // GetByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *TaskMongoRepository_Expecter) GetByID(ctx interface{}, id interface{}) *TaskMongoRepository_GetByID_Call {
-	return &TaskMongoRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+//   - ownerID string
+func (_e *TaskMongoRepository_Expecter) GetByID(ctx interface{}, id interface{}, ownerID interface{}) *TaskMongoRepository_GetByID_Call {
+	return &TaskMongoRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id, ownerID)}
 }
 
-func (_c *TaskMongoRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *TaskMongoRepository_GetByID_Call {
+func (_c *TaskMongoRepository_GetByID_Call) Run(run func(ctx context.Context, id string, ownerID string)) *TaskMongoRepository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -185,14 +247,14 @@ func (_c *TaskMongoRepository_GetByID_Call) Return(req *model.Task, err error) *
 	return _c
 }
 
-func (_c *TaskMongoRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*model.Task, error)) *TaskMongoRepository_GetByID_Call {
+func (_c *TaskMongoRepository_GetByID_Call) RunAndReturn(run func(context.Context, string, string) (*model.Task, error)) *TaskMongoRepository_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// List provides a mock function with given fields: ctx, filter, page, limit, sortField, sortOrder, searchText
-func (_m *TaskMongoRepository) List(ctx context.Context, filter primitive.M, page int64, limit int64, sortField string, sortOrder int, searchText string) ([]*model.Task, int64, error) {
-	ret := _m.Called(ctx, filter, page, limit, sortField, sortOrder, searchText)
+// List provides a mock function with given fields: ctx, filter, page, limit, sort, searchText
+func (_m *TaskMongoRepository) List(ctx context.Context, filter primitive.M, page int64, limit int64, sort []model.SortKey, searchText string) ([]*model.Task, int64, error) {
+	ret := _m.Called(ctx, filter, page, limit, sort, searchText)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -201,25 +263,25 @@ func (_m *TaskMongoRepository) List(ctx context.Context, filter primitive.M, pag
 	var r0 []*model.Task
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, primitive.M, int64, int64, string, int, string) ([]*model.Task, int64, error)); ok {
-		return rf(ctx, filter, page, limit, sortField, sortOrder, searchText)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.M, int64, int64, []model.SortKey, string) ([]*model.Task, int64, error)); ok {
+		return rf(ctx, filter, page, limit, sort, searchText)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, primitive.M, int64, int64, string, int, string) []*model.Task); ok {
-		r0 = rf(ctx, filter, page, limit, sortField, sortOrder, searchText)
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.M, int64, int64, []model.SortKey, string) []*model.Task); ok {
+		r0 = rf(ctx, filter, page, limit, sort, searchText)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, primitive.M, int64, int64, string, int, string) int64); ok {
-		r1 = rf(ctx, filter, page, limit, sortField, sortOrder, searchText)
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.M, int64, int64, []model.SortKey, string) int64); ok {
+		r1 = rf(ctx, filter, page, limit, sort, searchText)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, primitive.M, int64, int64, string, int, string) error); ok {
-		r2 = rf(ctx, filter, page, limit, sortField, sortOrder, searchText)
+	if rf, ok := ret.Get(2).(func(context.Context, primitive.M, int64, int64, []model.SortKey, string) error); ok {
+		r2 = rf(ctx, filter, page, limit, sort, searchText)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -237,16 +299,15 @@ type TaskMongoRepository_List_Call struct {
 //   - filter primitive.M
 //   - page int64
 //   - limit int64
-//   - sortField string
-//   - sortOrder int
+//   - sort []model.SortKey
 //   - searchText string
-func (_e *TaskMongoRepository_Expecter) List(ctx interface{}, filter interface{}, page interface{}, limit interface{}, sortField interface{}, sortOrder interface{}, searchText interface{}) *TaskMongoRepository_List_Call {
-	return &TaskMongoRepository_List_Call{Call: _e.mock.On("List", ctx, filter, page, limit, sortField, sortOrder, searchText)}
+func (_e *TaskMongoRepository_Expecter) List(ctx interface{}, filter interface{}, page interface{}, limit interface{}, sort interface{}, searchText interface{}) *TaskMongoRepository_List_Call {
+	return &TaskMongoRepository_List_Call{Call: _e.mock.On("List", ctx, filter, page, limit, sort, searchText)}
 }
 
-func (_c *TaskMongoRepository_List_Call) Run(run func(ctx context.Context, filter primitive.M, page int64, limit int64, sortField string, sortOrder int, searchText string)) *TaskMongoRepository_List_Call {
+func (_c *TaskMongoRepository_List_Call) Run(run func(ctx context.Context, filter primitive.M, page int64, limit int64, sort []model.SortKey, searchText string)) *TaskMongoRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(primitive.M), args[2].(int64), args[3].(int64), args[4].(string), args[5].(int), args[6].(string))
+		run(args[0].(context.Context), args[1].(primitive.M), args[2].(int64), args[3].(int64), args[4].([]model.SortKey), args[5].(string))
 	})
 	return _c
 }
@@ -256,14 +317,482 @@ func (_c *TaskMongoRepository_List_Call) Return(_a0 []*model.Task, _a1 int64, _a
 	return _c
 }
 
-func (_c *TaskMongoRepository_List_Call) RunAndReturn(run func(context.Context, primitive.M, int64, int64, string, int, string) ([]*model.Task, int64, error)) *TaskMongoRepository_List_Call {
+func (_c *TaskMongoRepository_List_Call) RunAndReturn(run func(context.Context, primitive.M, int64, int64, []model.SortKey, string) ([]*model.Task, int64, error)) *TaskMongoRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAfter provides a mock function with given fields: ctx, filter, limit, sort, searchText, cursor
+func (_m *TaskMongoRepository) ListAfter(ctx context.Context, filter primitive.M, limit int64, sort []model.SortKey, searchText string, cursor *model.TaskCursor) ([]*model.Task, error) {
+	ret := _m.Called(ctx, filter, limit, sort, searchText, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAfter")
+	}
+
+	var r0 []*model.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.M, int64, []model.SortKey, string, *model.TaskCursor) ([]*model.Task, error)); ok {
+		return rf(ctx, filter, limit, sort, searchText, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.M, int64, []model.SortKey, string, *model.TaskCursor) []*model.Task); ok {
+		r0 = rf(ctx, filter, limit, sort, searchText, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.M, int64, []model.SortKey, string, *model.TaskCursor) error); ok {
+		r1 = rf(ctx, filter, limit, sort, searchText, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_ListAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAfter'
+type TaskMongoRepository_ListAfter_Call struct {
+	*mock.Call
+}
+
+// ListAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter primitive.M
+//   - limit int64
+//   - sort []model.SortKey
+//   - searchText string
+//   - cursor *model.TaskCursor
+func (_e *TaskMongoRepository_Expecter) ListAfter(ctx interface{}, filter interface{}, limit interface{}, sort interface{}, searchText interface{}, cursor interface{}) *TaskMongoRepository_ListAfter_Call {
+	return &TaskMongoRepository_ListAfter_Call{Call: _e.mock.On("ListAfter", ctx, filter, limit, sort, searchText, cursor)}
+}
+
+func (_c *TaskMongoRepository_ListAfter_Call) Run(run func(ctx context.Context, filter primitive.M, limit int64, sort []model.SortKey, searchText string, cursor *model.TaskCursor)) *TaskMongoRepository_ListAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(primitive.M), args[2].(int64), args[3].([]model.SortKey), args[4].(string), args[5].(*model.TaskCursor))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_ListAfter_Call) Return(_a0 []*model.Task, _a1 error) *TaskMongoRepository_ListAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskMongoRepository_ListAfter_Call) RunAndReturn(run func(context.Context, primitive.M, int64, []model.SortKey, string, *model.TaskCursor) ([]*model.Task, error)) *TaskMongoRepository_ListAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByIDs provides a mock function with given fields: ctx, ids, ownerID
+func (_m *TaskMongoRepository) ListByIDs(ctx context.Context, ids []string, ownerID string) ([]*model.Task, error) {
+	ret := _m.Called(ctx, ids, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByIDs")
+	}
+
+	var r0 []*model.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) ([]*model.Task, error)); ok {
+		return rf(ctx, ids, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) []*model.Task); ok {
+		r0 = rf(ctx, ids, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, ids, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_ListByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByIDs'
+type TaskMongoRepository_ListByIDs_Call struct {
+	*mock.Call
+}
+
+// ListByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+//   - ownerID string
+func (_e *TaskMongoRepository_Expecter) ListByIDs(ctx interface{}, ids interface{}, ownerID interface{}) *TaskMongoRepository_ListByIDs_Call {
+	return &TaskMongoRepository_ListByIDs_Call{Call: _e.mock.On("ListByIDs", ctx, ids, ownerID)}
+}
+
+func (_c *TaskMongoRepository_ListByIDs_Call) Run(run func(ctx context.Context, ids []string, ownerID string)) *TaskMongoRepository_ListByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_ListByIDs_Call) Return(_a0 []*model.Task, _a1 error) *TaskMongoRepository_ListByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskMongoRepository_ListByIDs_Call) RunAndReturn(run func(context.Context, []string, string) ([]*model.Task, error)) *TaskMongoRepository_ListByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenBlockerIDs provides a mock function with given fields: ctx, ownerID
+func (_m *TaskMongoRepository) OpenBlockerIDs(ctx context.Context, ownerID string) ([]string, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBlockerIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_OpenBlockerIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenBlockerIDs'
+type TaskMongoRepository_OpenBlockerIDs_Call struct {
+	*mock.Call
+}
+
+// OpenBlockerIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *TaskMongoRepository_Expecter) OpenBlockerIDs(ctx interface{}, ownerID interface{}) *TaskMongoRepository_OpenBlockerIDs_Call {
+	return &TaskMongoRepository_OpenBlockerIDs_Call{Call: _e.mock.On("OpenBlockerIDs", ctx, ownerID)}
+}
+
+func (_c *TaskMongoRepository_OpenBlockerIDs_Call) Run(run func(ctx context.Context, ownerID string)) *TaskMongoRepository_OpenBlockerIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_OpenBlockerIDs_Call) Return(_a0 []string, _a1 error) *TaskMongoRepository_OpenBlockerIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskMongoRepository_OpenBlockerIDs_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *TaskMongoRepository_OpenBlockerIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purge provides a mock function with given fields: ctx, before
+func (_m *TaskMongoRepository) Purge(ctx context.Context, before time.Time) ([]string, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]string, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []string); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type TaskMongoRepository_Purge_Call struct {
+	*mock.Call
+}
+
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *TaskMongoRepository_Expecter) Purge(ctx interface{}, before interface{}) *TaskMongoRepository_Purge_Call {
+	return &TaskMongoRepository_Purge_Call{Call: _e.mock.On("Purge", ctx, before)}
+}
+
+func (_c *TaskMongoRepository_Purge_Call) Run(run func(ctx context.Context, before time.Time)) *TaskMongoRepository_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_Purge_Call) Return(_a0 []string, _a1 error) *TaskMongoRepository_Purge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskMongoRepository_Purge_Call) RunAndReturn(run func(context.Context, time.Time) ([]string, error)) *TaskMongoRepository_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveBlockers provides a mock function with given fields: ctx, ids
+func (_m *TaskMongoRepository) RemoveBlockers(ctx context.Context, ids []string) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBlockers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskMongoRepository_RemoveBlockers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveBlockers'
+type TaskMongoRepository_RemoveBlockers_Call struct {
+	*mock.Call
+}
+
+// RemoveBlockers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *TaskMongoRepository_Expecter) RemoveBlockers(ctx interface{}, ids interface{}) *TaskMongoRepository_RemoveBlockers_Call {
+	return &TaskMongoRepository_RemoveBlockers_Call{Call: _e.mock.On("RemoveBlockers", ctx, ids)}
+}
+
+func (_c *TaskMongoRepository_RemoveBlockers_Call) Run(run func(ctx context.Context, ids []string)) *TaskMongoRepository_RemoveBlockers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_RemoveBlockers_Call) Return(_a0 error) *TaskMongoRepository_RemoveBlockers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskMongoRepository_RemoveBlockers_Call) RunAndReturn(run func(context.Context, []string) error) *TaskMongoRepository_RemoveBlockers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameTag provides a mock function with given fields: ctx, ownerID, from, to
+func (_m *TaskMongoRepository) RenameTag(ctx context.Context, ownerID string, from string, to string) ([]*model.Task, error) {
+	ret := _m.Called(ctx, ownerID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameTag")
+	}
+
+	var r0 []*model.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]*model.Task, error)); ok {
+		return rf(ctx, ownerID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []*model.Task); ok {
+		r0 = rf(ctx, ownerID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, ownerID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_RenameTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameTag'
+type TaskMongoRepository_RenameTag_Call struct {
+	*mock.Call
+}
+
+// RenameTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - from string
+//   - to string
+func (_e *TaskMongoRepository_Expecter) RenameTag(ctx interface{}, ownerID interface{}, from interface{}, to interface{}) *TaskMongoRepository_RenameTag_Call {
+	return &TaskMongoRepository_RenameTag_Call{Call: _e.mock.On("RenameTag", ctx, ownerID, from, to)}
+}
+
+func (_c *TaskMongoRepository_RenameTag_Call) Run(run func(ctx context.Context, ownerID string, from string, to string)) *TaskMongoRepository_RenameTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_RenameTag_Call) Return(_a0 []*model.Task, _a1 error) *TaskMongoRepository_RenameTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskMongoRepository_RenameTag_Call) RunAndReturn(run func(context.Context, string, string, string) ([]*model.Task, error)) *TaskMongoRepository_RenameTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, id, ownerID
+func (_m *TaskMongoRepository) Restore(ctx context.Context, id string, ownerID string) (*model.Task, error) {
+	ret := _m.Called(ctx, id, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *model.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.Task, error)); ok {
+		return rf(ctx, id, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.Task); ok {
+		r0 = rf(ctx, id, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type TaskMongoRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerID string
+func (_e *TaskMongoRepository_Expecter) Restore(ctx interface{}, id interface{}, ownerID interface{}) *TaskMongoRepository_Restore_Call {
+	return &TaskMongoRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id, ownerID)}
+}
+
+func (_c *TaskMongoRepository_Restore_Call) Run(run func(ctx context.Context, id string, ownerID string)) *TaskMongoRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_Restore_Call) Return(res *model.Task, err error) *TaskMongoRepository_Restore_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskMongoRepository_Restore_Call) RunAndReturn(run func(context.Context, string, string) (*model.Task, error)) *TaskMongoRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tags provides a mock function with given fields: ctx, ownerID
+func (_m *TaskMongoRepository) Tags(ctx context.Context, ownerID string) ([]model.TagCount, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tags")
+	}
+
+	var r0 []model.TagCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.TagCount, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.TagCount); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TagCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskMongoRepository_Tags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tags'
+type TaskMongoRepository_Tags_Call struct {
+	*mock.Call
+}
+
+// Tags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *TaskMongoRepository_Expecter) Tags(ctx interface{}, ownerID interface{}) *TaskMongoRepository_Tags_Call {
+	return &TaskMongoRepository_Tags_Call{Call: _e.mock.On("Tags", ctx, ownerID)}
+}
+
+func (_c *TaskMongoRepository_Tags_Call) Run(run func(ctx context.Context, ownerID string)) *TaskMongoRepository_Tags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskMongoRepository_Tags_Call) Return(_a0 []model.TagCount, _a1 error) *TaskMongoRepository_Tags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskMongoRepository_Tags_Call) RunAndReturn(run func(context.Context, string) ([]model.TagCount, error)) *TaskMongoRepository_Tags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function with given fields: ctx, id, update
-func (_m *TaskMongoRepository) Update(ctx context.Context, id string, update primitive.M) (*model.Task, error) {
-	ret := _m.Called(ctx, id, update)
+// Update provides a mock function with given fields: ctx, id, ownerID, version, update
+func (_m *TaskMongoRepository) Update(ctx context.Context, id string, ownerID string, version int64, update primitive.M) (*model.Task, error) {
+	ret := _m.Called(ctx, id, ownerID, version, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -271,19 +800,19 @@ func (_m *TaskMongoRepository) Update(ctx context.Context, id string, update pri
 
 	var r0 *model.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.M) (*model.Task, error)); ok {
-		return rf(ctx, id, update)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, primitive.M) (*model.Task, error)); ok {
+		return rf(ctx, id, ownerID, version, update)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.M) *model.Task); ok {
-		r0 = rf(ctx, id, update)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, primitive.M) *model.Task); ok {
+		r0 = rf(ctx, id, ownerID, version, update)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, primitive.M) error); ok {
-		r1 = rf(ctx, id, update)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, primitive.M) error); ok {
+		r1 = rf(ctx, id, ownerID, version, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -299,14 +828,16 @@ type TaskMongoRepository_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
+//   - ownerID string
+//   - version int64
 //   - update primitive.M
-func (_e *TaskMongoRepository_Expecter) Update(ctx interface{}, id interface{}, update interface{}) *TaskMongoRepository_Update_Call {
-	return &TaskMongoRepository_Update_Call{Call: _e.mock.On("Update", ctx, id, update)}
+func (_e *TaskMongoRepository_Expecter) Update(ctx interface{}, id interface{}, ownerID interface{}, version interface{}, update interface{}) *TaskMongoRepository_Update_Call {
+	return &TaskMongoRepository_Update_Call{Call: _e.mock.On("Update", ctx, id, ownerID, version, update)}
 }
 
-func (_c *TaskMongoRepository_Update_Call) Run(run func(ctx context.Context, id string, update primitive.M)) *TaskMongoRepository_Update_Call {
+func (_c *TaskMongoRepository_Update_Call) Run(run func(ctx context.Context, id string, ownerID string, version int64, update primitive.M)) *TaskMongoRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(primitive.M))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64), args[4].(primitive.M))
 	})
 	return _c
 }
@@ -316,7 +847,7 @@ func (_c *TaskMongoRepository_Update_Call) Return(res *model.Task, err error) *T
 	return _c
 }
 
-func (_c *TaskMongoRepository_Update_Call) RunAndReturn(run func(context.Context, string, primitive.M) (*model.Task, error)) *TaskMongoRepository_Update_Call {
+func (_c *TaskMongoRepository_Update_Call) RunAndReturn(run func(context.Context, string, string, int64, primitive.M) (*model.Task, error)) *TaskMongoRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,324 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaskViewMongoRepository is an autogenerated mock type for the TaskViewMongoRepository type
+type TaskViewMongoRepository struct {
+	mock.Mock
+}
+
+type TaskViewMongoRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TaskViewMongoRepository) EXPECT() *TaskViewMongoRepository_Expecter {
+	return &TaskViewMongoRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *TaskViewMongoRepository) Create(ctx context.Context, req *model.TaskView) (*model.TaskView, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.TaskView) (*model.TaskView, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.TaskView) *model.TaskView); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.TaskView) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewMongoRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type TaskViewMongoRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *model.TaskView
+func (_e *TaskViewMongoRepository_Expecter) Create(ctx interface{}, req interface{}) *TaskViewMongoRepository_Create_Call {
+	return &TaskViewMongoRepository_Create_Call{Call: _e.mock.On("Create", ctx, req)}
+}
+
+func (_c *TaskViewMongoRepository_Create_Call) Run(run func(ctx context.Context, req *model.TaskView)) *TaskViewMongoRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.TaskView))
+	})
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_Create_Call) Return(res *model.TaskView, err error) *TaskViewMongoRepository_Create_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_Create_Call) RunAndReturn(run func(context.Context, *model.TaskView) (*model.TaskView, error)) *TaskViewMongoRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *TaskViewMongoRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskViewMongoRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type TaskViewMongoRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *TaskViewMongoRepository_Expecter) Delete(ctx interface{}, id interface{}) *TaskViewMongoRepository_Delete_Call {
+	return &TaskViewMongoRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *TaskViewMongoRepository_Delete_Call) Run(run func(ctx context.Context, id string)) *TaskViewMongoRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_Delete_Call) Return(_a0 error) *TaskViewMongoRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_Delete_Call) RunAndReturn(run func(context.Context, string) error) *TaskViewMongoRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *TaskViewMongoRepository) GetByID(ctx context.Context, id string) (*model.TaskView, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.TaskView, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.TaskView); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewMongoRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type TaskViewMongoRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *TaskViewMongoRepository_Expecter) GetByID(ctx interface{}, id interface{}) *TaskViewMongoRepository_GetByID_Call {
+	return &TaskViewMongoRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *TaskViewMongoRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *TaskViewMongoRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_GetByID_Call) Return(res *model.TaskView, err error) *TaskViewMongoRepository_GetByID_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*model.TaskView, error)) *TaskViewMongoRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, ownerID
+func (_m *TaskViewMongoRepository) List(ctx context.Context, ownerID string) ([]*model.TaskView, error) {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.TaskView, error)); ok {
+		return rf(ctx, ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.TaskView); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewMongoRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type TaskViewMongoRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *TaskViewMongoRepository_Expecter) List(ctx interface{}, ownerID interface{}) *TaskViewMongoRepository_List_Call {
+	return &TaskViewMongoRepository_List_Call{Call: _e.mock.On("List", ctx, ownerID)}
+}
+
+func (_c *TaskViewMongoRepository_List_Call) Run(run func(ctx context.Context, ownerID string)) *TaskViewMongoRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_List_Call) Return(_a0 []*model.TaskView, _a1 error) *TaskViewMongoRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_List_Call) RunAndReturn(run func(context.Context, string) ([]*model.TaskView, error)) *TaskViewMongoRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, id, data
+func (_m *TaskViewMongoRepository) Update(ctx context.Context, id string, data primitive.M) (*model.TaskView, error) {
+	ret := _m.Called(ctx, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *model.TaskView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.M) (*model.TaskView, error)); ok {
+		return rf(ctx, id, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.M) *model.TaskView); ok {
+		r0 = rf(ctx, id, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaskView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, primitive.M) error); ok {
+		r1 = rf(ctx, id, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskViewMongoRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TaskViewMongoRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - data primitive.M
+func (_e *TaskViewMongoRepository_Expecter) Update(ctx interface{}, id interface{}, data interface{}) *TaskViewMongoRepository_Update_Call {
+	return &TaskViewMongoRepository_Update_Call{Call: _e.mock.On("Update", ctx, id, data)}
+}
+
+func (_c *TaskViewMongoRepository_Update_Call) Run(run func(ctx context.Context, id string, data primitive.M)) *TaskViewMongoRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(primitive.M))
+	})
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_Update_Call) Return(res *model.TaskView, err error) *TaskViewMongoRepository_Update_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *TaskViewMongoRepository_Update_Call) RunAndReturn(run func(context.Context, string, primitive.M) (*model.TaskView, error)) *TaskViewMongoRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTaskViewMongoRepository creates a new instance of TaskViewMongoRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskViewMongoRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskViewMongoRepository {
+	mock := &TaskViewMongoRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
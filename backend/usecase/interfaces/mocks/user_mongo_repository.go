@@ -0,0 +1,215 @@
+// Code generated by mockery v2.38.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/hendrihmwn/crud-task-backend/model"
+)
+
+// UserMongoRepository is an autogenerated mock type for the UserMongoRepository type
+type UserMongoRepository struct {
+	mock.Mock
+}
+
+type UserMongoRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserMongoRepository) EXPECT() *UserMongoRepository_Expecter {
+	return &UserMongoRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, req
+func (_m *UserMongoRepository) Create(ctx context.Context, req *model.User) (*model.User, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) (*model.User, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User) *model.User); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserMongoRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type UserMongoRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *model.User
+func (_e *UserMongoRepository_Expecter) Create(ctx interface{}, req interface{}) *UserMongoRepository_Create_Call {
+	return &UserMongoRepository_Create_Call{Call: _e.mock.On("Create", ctx, req)}
+}
+
+func (_c *UserMongoRepository_Create_Call) Run(run func(ctx context.Context, req *model.User)) *UserMongoRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*model.User))
+	})
+	return _c
+}
+
+func (_c *UserMongoRepository_Create_Call) Return(res *model.User, err error) *UserMongoRepository_Create_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *UserMongoRepository_Create_Call) RunAndReturn(run func(context.Context, *model.User) (*model.User, error)) *UserMongoRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *UserMongoRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserMongoRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type UserMongoRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *UserMongoRepository_Expecter) GetByID(ctx interface{}, id interface{}) *UserMongoRepository_GetByID_Call {
+	return &UserMongoRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *UserMongoRepository_GetByID_Call) Run(run func(ctx context.Context, id string)) *UserMongoRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserMongoRepository_GetByID_Call) Return(res *model.User, err error) *UserMongoRepository_GetByID_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *UserMongoRepository_GetByID_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *UserMongoRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *UserMongoRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUsername")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserMongoRepository_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
+type UserMongoRepository_GetByUsername_Call struct {
+	*mock.Call
+}
+
+// GetByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *UserMongoRepository_Expecter) GetByUsername(ctx interface{}, username interface{}) *UserMongoRepository_GetByUsername_Call {
+	return &UserMongoRepository_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
+}
+
+func (_c *UserMongoRepository_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *UserMongoRepository_GetByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserMongoRepository_GetByUsername_Call) Return(res *model.User, err error) *UserMongoRepository_GetByUsername_Call {
+	_c.Call.Return(res, err)
+	return _c
+}
+
+func (_c *UserMongoRepository_GetByUsername_Call) RunAndReturn(run func(context.Context, string) (*model.User, error)) *UserMongoRepository_GetByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserMongoRepository creates a new instance of UserMongoRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserMongoRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserMongoRepository {
+	mock := &UserMongoRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:generate mockery --name=RefreshTokenMongoRepository --keeptree --output=mocks --case=underscore --with-expecter=true
+type RefreshTokenMongoRepository interface {
+	Create(ctx context.Context, req *model.RefreshToken) (res *model.RefreshToken, err error)
+	GetByHash(ctx context.Context, hash string) (res *model.RefreshToken, err error)
+	MarkUsed(ctx context.Context, id primitive.ObjectID) error
+	RevokeFamily(ctx context.Context, familyID string) error
+}
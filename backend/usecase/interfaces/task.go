@@ -4,13 +4,39 @@ import (
 	"context"
 	"github.com/hendrihmwn/crud-task-backend/model"
 	"go.mongodb.org/mongo-driver/bson"
+	"time"
 )
 
 //go:generate mockery --name=TaskMongoRepository --keeptree --output=mocks --case=underscore --with-expecter=true
 type TaskMongoRepository interface {
-	List(ctx context.Context, filter bson.M, page, limit int64, sortField string, sortOrder int, searchText string) ([]*model.Task, int64, error)
-	GetByID(ctx context.Context, id string) (req *model.Task, err error)
+	// List leaves out the tasks in the trash unless filter has a deleted_at condition.
+	// searchText is a full-text search, which a "relevance" sort key ranks by score.
+	List(ctx context.Context, filter bson.M, page, limit int64, sort []model.SortKey, searchText string) ([]*model.Task, int64, error)
+	// ListAfter is List for keyset pagination: up to limit tasks after cursor, the first ones when it is nil.
+	// Both break ties in sort on _id.
+	ListAfter(ctx context.Context, filter bson.M, limit int64, sort []model.SortKey, searchText string, cursor *model.TaskCursor) ([]*model.Task, error)
+	// GetByID, Update and Delete only see tasks that are not in the trash.
+	// ownerID restricts GetByID, Update, Delete and Restore to that owner, an empty ownerID matches any owner.
+	// A non-zero version makes Update and Delete only match that version of the task.
+	GetByID(ctx context.Context, id, ownerID string) (req *model.Task, err error)
+	// ListByIDs also returns tasks in the trash, OpenBlockerIDs lists the open
+	// tasks that appear in the blocked_by of another task
+	ListByIDs(ctx context.Context, ids []string, ownerID string) ([]*model.Task, error)
+	OpenBlockerIDs(ctx context.Context, ownerID string) ([]string, error)
+	// BumpVersions gives each task a new version if it is still at the version
+	// it has, and returns how many were
+	BumpVersions(ctx context.Context, tasks []*model.Task) (int64, error)
 	Create(ctx context.Context, req *model.Task) (res *model.Task, err error)
-	Update(ctx context.Context, id string, update bson.M) (res *model.Task, err error)
-	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, id, ownerID string, version int64, update bson.M) (res *model.Task, err error)
+	// Delete moves the task to the trash, Purge removes what was trashed before
+	// the given time for good and returns the removed ids. RemoveBlockers takes
+	// ids out of every blocked_by.
+	Delete(ctx context.Context, id, ownerID string, version int64, deletedBy string) error
+	Restore(ctx context.Context, id, ownerID string) (res *model.Task, err error)
+	Purge(ctx context.Context, before time.Time) ([]string, error)
+	RemoveBlockers(ctx context.Context, ids []string) error
+	// Tags counts the tasks per tag outside the trash, RenameTag renames a tag on
+	// every task of ownerID and returns those tasks as they were before
+	Tags(ctx context.Context, ownerID string) ([]model.TagCount, error)
+	RenameTag(ctx context.Context, ownerID, from, to string) ([]*model.Task, error)
 }
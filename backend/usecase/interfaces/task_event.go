@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+)
+
+//go:generate mockery --name=TaskEventRepository --keeptree --output=mocks --case=underscore --with-expecter=true
+type TaskEventRepository interface {
+	Create(ctx context.Context, req *model.TaskEvent) error
+	// ownerID restricts ListByTask to the events of that owner, an empty ownerID matches any owner
+	ListByTask(ctx context.Context, taskID, ownerID string, page, limit int64) ([]*model.TaskEvent, int64, error)
+	DeleteByTasks(ctx context.Context, taskIDs []string) error
+}
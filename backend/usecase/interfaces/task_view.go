@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//go:generate mockery --name=TaskViewMongoRepository --keeptree --output=mocks --case=underscore --with-expecter=true
+type TaskViewMongoRepository interface {
+	Create(ctx context.Context, req *model.TaskView) (res *model.TaskView, err error)
+	GetByID(ctx context.Context, id string) (res *model.TaskView, err error)
+	// List returns the views of ownerID and the shared ones, every view when ownerID is empty
+	List(ctx context.Context, ownerID string) ([]*model.TaskView, error)
+	Update(ctx context.Context, id string, data bson.M) (res *model.TaskView, err error)
+	Delete(ctx context.Context, id string) error
+}
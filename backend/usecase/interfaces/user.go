@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+)
+
+//go:generate mockery --name=UserMongoRepository --keeptree --output=mocks --case=underscore --with-expecter=true
+type UserMongoRepository interface {
+	Create(ctx context.Context, req *model.User) (res *model.User, err error)
+	GetByUsername(ctx context.Context, username string) (res *model.User, err error)
+	GetByID(ctx context.Context, id string) (res *model.User, err error)
+}
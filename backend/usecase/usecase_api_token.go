@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces"
+	"go.mongodb.org/mongo-driver/mongo"
+	"strings"
+	"time"
+)
+
+// last_used_at is only written once per interval so busy scripts don't turn
+// every request into a database write
+const apiTokenTouchInterval = time.Minute
+
+type APITokenUseCase struct {
+	APITokenMongoRepository interfaces.APITokenMongoRepository
+	UserMongoRepository     interfaces.UserMongoRepository
+}
+
+func NewAPITokenUseCase(apiTokenMongoRepository interfaces.APITokenMongoRepository, userMongoRepository interfaces.UserMongoRepository) APITokenUseCase {
+	return APITokenUseCase{
+		APITokenMongoRepository: apiTokenMongoRepository,
+		UserMongoRepository:     userMongoRepository,
+	}
+}
+
+func (a APITokenUseCase) CreateToken(ctx context.Context, principal model.Principal, body model.APITokenBodyParam) (res *model.APITokenResponse, err error) {
+	secret, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	token := model.APITokenPrefix + secret
+
+	var expiresAt *time.Time
+	if body.ExpiresInDays > 0 {
+		at := time.Now().UTC().AddDate(0, 0, body.ExpiresInDays)
+		expiresAt = &at
+	}
+
+	created, err := a.APITokenMongoRepository.Create(ctx, &model.APIToken{
+		UserID:    principal.UserID,
+		Name:      body.Name,
+		Scopes:    dedupe(body.Scopes),
+		TokenHash: hashToken(token),
+		Prefix:    token[:len(model.APITokenPrefix)+8],
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = toAPITokenResponse(created)
+	res.Token = token
+	return
+}
+
+func (a APITokenUseCase) ListTokens(ctx context.Context, principal model.Principal) (res []model.APITokenResponse, err error) {
+	list, err := a.APITokenMongoRepository.ListByUser(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	res = []model.APITokenResponse{}
+	for _, v := range list {
+		res = append(res, *toAPITokenResponse(v))
+	}
+	return
+}
+
+func (a APITokenUseCase) RevokeToken(ctx context.Context, principal model.Principal, id string) (err error) {
+	return notFound(a.APITokenMongoRepository.Revoke(ctx, id, principal.UserID))
+}
+
+// Authenticate resolves a personal access token to its owner, carrying the
+// owner's current role and the token scopes
+func (a APITokenUseCase) Authenticate(ctx context.Context, token string) (res model.Principal, err error) {
+	if !strings.HasPrefix(token, model.APITokenPrefix) {
+		return res, ErrInvalidAPIToken
+	}
+
+	stored, err := a.APITokenMongoRepository.GetByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ErrInvalidAPIToken
+		}
+		return
+	}
+	now := time.Now().UTC()
+	if stored.RevokedAt != nil || (stored.ExpiresAt != nil && now.After(*stored.ExpiresAt)) {
+		return res, ErrInvalidAPIToken
+	}
+
+	user, err := a.UserMongoRepository.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ErrInvalidAPIToken
+		}
+		return
+	}
+
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) > apiTokenTouchInterval {
+		// failing to record usage must not fail the request
+		_ = a.APITokenMongoRepository.TouchLastUsed(ctx, stored.ID, now)
+	}
+
+	res = model.Principal{
+		UserID: stored.UserID,
+		Role:   userRole(user),
+		Scopes: stored.Scopes,
+	}
+	return
+}
+
+func toAPITokenResponse(t *model.APIToken) *model.APITokenResponse {
+	return &model.APITokenResponse{
+		ID:         t.ID.Hex(),
+		Name:       t.Name,
+		Scopes:     t.Scopes,
+		Prefix:     t.Prefix,
+		CreatedAt:  t.CreatedAt,
+		LastUsedAt: t.LastUsedAt,
+		ExpiresAt:  t.ExpiresAt,
+	}
+}
+
+func dedupe(values []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
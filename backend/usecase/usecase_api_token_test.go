@@ -0,0 +1,308 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"strings"
+	"testing"
+	"time"
+)
+
+type APITokenUseCaseTestSuite struct {
+	suite.Suite
+
+	APITokenMongoRepository *mocks.APITokenMongoRepository
+	UserMongoRepository     *mocks.UserMongoRepository
+	UseCase                 usecase.APITokenUseCase
+}
+
+func TestAPITokenUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(APITokenUseCaseTestSuite))
+}
+
+func (s *APITokenUseCaseTestSuite) SetupTest() {
+	s.APITokenMongoRepository = mocks.NewAPITokenMongoRepository(s.T())
+	s.UserMongoRepository = mocks.NewUserMongoRepository(s.T())
+	s.UseCase = usecase.NewAPITokenUseCase(
+		s.APITokenMongoRepository,
+		s.UserMongoRepository,
+	)
+}
+
+func (s *APITokenUseCaseTestSuite) TestCreateToken() {
+	type args struct {
+		ctx    context.Context
+		params model.APITokenBodyParam
+	}
+	tests := []struct {
+		name       string
+		args       args
+		mock       func()
+		afterTest  func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "error - create",
+			args: args{
+				ctx: context.TODO(),
+				params: model.APITokenBodyParam{
+					Name:   "ci",
+					Scopes: []string{model.ScopeTasksRead},
+				},
+			},
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().Create(mock.Anything, mock.Anything).
+					Return(nil, errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.TODO(),
+				params: model.APITokenBodyParam{
+					Name:          "ci",
+					Scopes:        []string{model.ScopeTasksRead, model.ScopeTasksRead, model.ScopeTasksWrite},
+					ExpiresInDays: 30,
+				},
+			},
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(t *model.APIToken) bool {
+					return t.UserID == "user-1" && t.TokenHash != "" && strings.HasPrefix(t.Prefix, model.APITokenPrefix) &&
+						len(t.Scopes) == 2 && t.ExpiresAt != nil
+				})).
+					RunAndReturn(func(_ context.Context, t *model.APIToken) (*model.APIToken, error) {
+						t.ID = primitive.NewObjectID()
+						return t, nil
+					}).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			res, err := s.UseCase.CreateToken(tt.args.ctx, member, tt.args.params)
+			tt.afterTest()
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+				s.True(strings.HasPrefix(res.Token, res.Prefix))
+			}
+		})
+	}
+}
+
+func (s *APITokenUseCaseTestSuite) TestListTokens() {
+	tests := []struct {
+		name       string
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+		size       int
+	}{
+		{
+			name: "error - list",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().ListByUser(mock.Anything, "user-1").
+					Return(nil, errors.New("some error")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "success",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().ListByUser(mock.Anything, "user-1").
+					Return([]*model.APIToken{{
+						ID:     primitive.NewObjectID(),
+						Name:   "ci",
+						Scopes: []string{model.ScopeTasksRead},
+					}}, nil).Once()
+			},
+			size: 1,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			res, err := s.UseCase.ListTokens(context.TODO(), member)
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+				s.Len(res, tt.size)
+			}
+		})
+	}
+}
+
+func (s *APITokenUseCaseTestSuite) TestRevokeToken() {
+	tests := []struct {
+		name       string
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "error - not found",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().Revoke(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(mongo.ErrNoDocuments).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "data not found",
+		},
+		{
+			name: "success",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().Revoke(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			err := s.UseCase.RevokeToken(context.TODO(), member, "68fc6a818c54acf4a737d7ab")
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *APITokenUseCaseTestSuite) TestAuthenticate() {
+	userID := primitive.NewObjectID()
+	past := time.Now().Add(-time.Hour)
+	recent := time.Now().Add(-time.Second)
+	stored := func(mutate func(t *model.APIToken)) *model.APIToken {
+		t := &model.APIToken{
+			ID:     primitive.NewObjectID(),
+			UserID: userID.Hex(),
+			Scopes: []string{model.ScopeTasksRead},
+		}
+		mutate(t)
+		return t
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:       "error - not a personal access token",
+			token:      "eyJhbGciOi",
+			mock:       func() {},
+			wantErr:    true,
+			wantErrMsg: "invalid api token",
+		},
+		{
+			name:  "error - unknown token",
+			token: "pat_xxx",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid api token",
+		},
+		{
+			name:  "error - revoked",
+			token: "pat_xxx",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.APIToken) { t.RevokedAt = &past }), nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid api token",
+		},
+		{
+			name:  "error - expired",
+			token: "pat_xxx",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.APIToken) { t.ExpiresAt = &past }), nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid api token",
+		},
+		{
+			name:  "error - owner removed",
+			token: "pat_xxx",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.APIToken) {}), nil).Once()
+				s.UserMongoRepository.EXPECT().GetByID(mock.Anything, userID.Hex()).
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid api token",
+		},
+		{
+			name:  "success - records last use",
+			token: "pat_xxx",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.APIToken) {}), nil).Once()
+				s.UserMongoRepository.EXPECT().GetByID(mock.Anything, userID.Hex()).
+					Return(&model.User{ID: userID, Role: model.RoleViewer}, nil).Once()
+				s.APITokenMongoRepository.EXPECT().TouchLastUsed(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+			},
+		},
+		{
+			name:  "success - recently used is not written again",
+			token: "pat_xxx",
+			mock: func() {
+				s.APITokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.APIToken) { t.LastUsedAt = &recent }), nil).Once()
+				s.UserMongoRepository.EXPECT().GetByID(mock.Anything, userID.Hex()).
+					Return(&model.User{ID: userID, Role: model.RoleViewer}, nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			res, err := s.UseCase.Authenticate(context.TODO(), tt.token)
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+				s.Equal(userID.Hex(), res.UserID)
+				s.Equal(model.RoleViewer, res.Role)
+				s.Equal([]string{model.ScopeTasksRead}, res.Scopes)
+			}
+		})
+	}
+}
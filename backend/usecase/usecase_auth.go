@@ -2,50 +2,308 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/hendrihmwn/crud-task-backend/helper"
 	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
 	"time"
 )
 
+// dummyPasswordHash is compared against when the username is unknown, so a
+// login takes as long whether the account exists or not. It has the cost of
+// the stored hashes.
+const dummyPasswordHash = "$2a$10$uGRk/l0Eg0.HnAIY3uTENOMSDb2VXozgPG1ZRzy5GYe.lNDyljeyu"
+
 type AuthUseCase struct {
-	config helper.Config
+	config                      helper.Config
+	UserMongoRepository         interfaces.UserMongoRepository
+	RefreshTokenMongoRepository interfaces.RefreshTokenMongoRepository
+	LoginAttemptRepository      interfaces.LoginAttemptRepository
 }
 
-func NewAuthUseCase(config helper.Config) AuthUseCase {
+func NewAuthUseCase(config helper.Config, userMongoRepository interfaces.UserMongoRepository, refreshTokenMongoRepository interfaces.RefreshTokenMongoRepository, loginAttemptRepository interfaces.LoginAttemptRepository) AuthUseCase {
 	return AuthUseCase{
-		config: config,
+		config:                      config,
+		UserMongoRepository:         userMongoRepository,
+		RefreshTokenMongoRepository: refreshTokenMongoRepository,
+		LoginAttemptRepository:      loginAttemptRepository,
+	}
+}
+
+func (a AuthUseCase) Register(ctx context.Context, param model.RegisterParam) (res model.UserResponse, err error) {
+	// bcrypt reads at most 72 bytes, the binding only counts characters
+	if len([]byte(param.Password)) > 72 {
+		err = ErrPasswordTooLong
+		return
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(param.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return
+	}
+
+	// admins are promoted directly in the database, never through self registration
+	created, err := a.UserMongoRepository.Create(ctx, &model.User{
+		Username:     normalizeUsername(param.Username),
+		PasswordHash: string(hash),
+		Role:         model.RoleMember,
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = ErrUsernameTaken
+		}
+		return
+	}
+
+	res = model.UserResponse{
+		ID:        created.ID.Hex(),
+		Username:  created.Username,
+		Role:      created.Role,
+		CreatedAt: created.CreatedAt,
 	}
+	return
 }
 
+// Login checks the credentials. Failures are counted per username and per
+// client address, see loginFailed, and a locked key gets a LoginLockedError
+// before the password is even looked at.
 func (a AuthUseCase) Login(ctx context.Context, param model.LoginParam) (res model.AuthResponse, err error) {
-	// Mock credentials
-	const mockUser = "admin"
-	const mockPass = "password"
-	jwtSecret := a.config.JWTSecret
+	username := normalizeUsername(param.Username)
+	keys := a.loginAttemptKeys(username, param.IP)
+	if err = a.checkLoginLock(ctx, keys); err != nil {
+		return
+	}
+
+	user, err := a.UserMongoRepository.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(param.Password))
+			err = a.loginFailed(ctx, keys)
+		}
+		return
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(param.Password)) != nil {
+		err = a.loginFailed(ctx, keys)
+		return
+	}
+
+	// only the username counter is cleared, otherwise logging into one's own
+	// account would reset the counter of an address guessing other accounts
+	if err = a.LoginAttemptRepository.Reset(ctx, keys[0].key); err != nil {
+		return
+	}
+
+	// every login starts a new refresh token family
+	return a.issueTokens(ctx, user, primitive.NewObjectID().Hex(), param.UserAgent, param.IP)
+}
+
+// Refresh rotates the presented refresh token. A token that was already used
+// means it leaked, so the whole family is revoked and the user must log in again.
+func (a AuthUseCase) Refresh(ctx context.Context, param model.RefreshTokenParam) (res model.AuthResponse, err error) {
+	stored, err := a.RefreshTokenMongoRepository.GetByHash(ctx, hashToken(param.RefreshToken))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ErrInvalidRefreshToken
+		}
+		return
+	}
+
+	if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		err = ErrInvalidRefreshToken
+		return
+	}
+	if stored.UsedAt != nil {
+		return res, a.revokeReusedFamily(ctx, stored.FamilyID)
+	}
+
+	err = a.RefreshTokenMongoRepository.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		// lost the race against another request presenting the same token
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return res, a.revokeReusedFamily(ctx, stored.FamilyID)
+		}
+		return
+	}
+
+	// reload the user so role changes apply on the next refresh
+	user, err := a.UserMongoRepository.GetByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = ErrInvalidRefreshToken
+		}
+		return
+	}
+
+	return a.issueTokens(ctx, user, stored.FamilyID, param.UserAgent, param.IP)
+}
 
-	if param.Username != mockUser || param.Password != mockPass {
-		err = errors.New("invalid username or password")
+// Logout revokes the family of the presented refresh token. Unknown tokens are
+// ignored so logging out twice is not an error.
+func (a AuthUseCase) Logout(ctx context.Context, param model.RefreshTokenParam) (err error) {
+	stored, err := a.RefreshTokenMongoRepository.GetByHash(ctx, hashToken(param.RefreshToken))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
 		return
 	}
+	return a.RefreshTokenMongoRepository.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (a AuthUseCase) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := a.RefreshTokenMongoRepository.RevokeFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+type loginAttemptKey struct {
+	key     string
+	limit   int
+	backoff bool
+}
+
+func (a AuthUseCase) loginAttemptKeys(username, ip string) []loginAttemptKey {
+	keys := []loginAttemptKey{{key: "user:" + username, limit: a.config.LoginMaxAttempts, backoff: true}}
+	if ip != "" {
+		// no backoff per address, a shared NAT would slow down everyone behind it
+		keys = append(keys, loginAttemptKey{key: "ip:" + ip, limit: a.config.LoginMaxAttemptsPerIP})
+	}
+	return keys
+}
+
+func (a AuthUseCase) checkLoginLock(ctx context.Context, keys []loginAttemptKey) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, k := range keys {
+		attempt, err := a.LoginAttemptRepository.Get(ctx, k.key)
+		if err != nil {
+			return err
+		}
+		if attempt != nil && attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			retryAfter = max(retryAfter, attempt.LockedUntil.Sub(now))
+		}
+	}
+	if retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
 
-	// Create JWT token (HMAC SHA256)
-	expiryTime := time.Now().Add(24 * time.Hour).Unix()
+// loginFailed counts the failure on every key and locks the keys that have to
+// wait, then reports the credentials as invalid. Counters are forgotten after
+// LoginLockout without failures.
+func (a AuthUseCase) loginFailed(ctx context.Context, keys []loginAttemptKey) error {
+	now := time.Now()
+	for _, k := range keys {
+		attempt, err := a.LoginAttemptRepository.RecordFailure(ctx, k.key, now, a.config.LoginLockout)
+		if err != nil {
+			return err
+		}
+		if delay := a.loginDelay(k, attempt.Failures); delay > 0 {
+			if err := a.LoginAttemptRepository.Lock(ctx, k.key, now.Add(delay)); err != nil {
+				return err
+			}
+		}
+	}
+	return ErrInvalidCredentials
+}
+
+// loginDelay is the lock after the given number of consecutive failures: the
+// first failure is free, then LoginBackoff doubles each time until the limit
+// is reached and the key is locked out for LoginLockout.
+func (a AuthUseCase) loginDelay(k loginAttemptKey, failures int) time.Duration {
+	lockout := a.config.LoginLockout
+	if k.limit > 0 && failures >= k.limit {
+		return lockout
+	}
+	if !k.backoff || failures < 2 || a.config.LoginBackoff <= 0 {
+		return 0
+	}
+	delay := a.config.LoginBackoff << min(failures-2, 20)
+	if lockout > 0 && delay > lockout {
+		delay = lockout
+	}
+	return delay
+}
+
+func (a AuthUseCase) issueTokens(ctx context.Context, user *model.User, familyID, userAgent, ip string) (res model.AuthResponse, err error) {
+	now := time.Now()
+	userID := user.ID.Hex()
+
+	// signed with the active key, see helper.TokenKeys
+	expiryTime := now.Add(a.config.AccessTokenTTL).Unix()
 	claims := jwt.MapClaims{
-		"sub": param.Username,
-		"iat": time.Now().Unix(),
-		"exp": expiryTime,
+		"iss":  a.config.JWTIssuer,
+		"aud":  a.config.JWTAudience,
+		"sub":  userID,
+		"role": userRole(user),
+		"iat":  now.Unix(),
+		"nbf":  now.Unix(),
+		"exp":  expiryTime,
+	}
+	signed, err := a.config.SigningKeys().Sign(claims)
+	if err != nil {
+		return
+	}
+
+	refreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return
 	}
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := t.SignedString([]byte(jwtSecret))
+	refreshExpiry := now.Add(a.config.RefreshTokenTTL).UTC()
+	_, err = a.RefreshTokenMongoRepository.Create(ctx, &model.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		UserAgent: userAgent,
+		IP:        ip,
+		ExpiresAt: refreshExpiry,
+	})
 	if err != nil {
 		return
 	}
 
 	res = model.AuthResponse{
-		Token:          signed,
-		ExpirationTime: time.Now().Add(24 * time.Hour).Unix(),
+		Token:                 signed,
+		ExpirationTime:        expiryTime,
+		RefreshToken:          refreshToken,
+		RefreshExpirationTime: refreshExpiry.Unix(),
 	}
 	return
 }
+
+// accounts created before roles existed are members
+func userRole(user *model.User) string {
+	if user.Role == "" {
+		return model.RoleMember
+	}
+	return user.Role
+}
+
+// usernames are matched case-insensitively, so they are stored lower-cased
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// opaque tokens are random enough that a fast hash is sufficient for storage
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,18 +2,29 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"github.com/hendrihmwn/crud-task-backend/helper"
 	"github.com/hendrihmwn/crud-task-backend/model"
 	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
 	"testing"
+	"time"
 )
 
 type AuthUseCaseTestSuite struct {
 	suite.Suite
 
-	Config  helper.Config
-	UseCase usecase.AuthUseCase
+	Config                      helper.Config
+	UserMongoRepository         *mocks.UserMongoRepository
+	RefreshTokenMongoRepository *mocks.RefreshTokenMongoRepository
+	LoginAttemptRepository      *mocks.LoginAttemptRepository
+	UseCase                     usecase.AuthUseCase
 }
 
 func TestAuthUseCaseSuite(t *testing.T) {
@@ -22,12 +33,27 @@ func TestAuthUseCaseSuite(t *testing.T) {
 
 func (s *AuthUseCaseTestSuite) SetupTest() {
 	s.Config = helper.LoadConfig()
+	s.UserMongoRepository = mocks.NewUserMongoRepository(s.T())
+	s.RefreshTokenMongoRepository = mocks.NewRefreshTokenMongoRepository(s.T())
+	s.LoginAttemptRepository = mocks.NewLoginAttemptRepository(s.T())
 	s.UseCase = usecase.NewAuthUseCase(
 		s.Config,
+		s.UserMongoRepository,
+		s.RefreshTokenMongoRepository,
+		s.LoginAttemptRepository,
 	)
 }
 
 func (s *AuthUseCaseTestSuite) TestLogin() {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &model.User{
+		ID:           primitive.NewObjectID(),
+		Username:     "admin",
+		PasswordHash: string(hash),
+		CreatedAt:    time.Now(),
+	}
+	lockedUntil := time.Now().Add(time.Minute)
+
 	type args struct {
 		ctx    context.Context
 		params model.LoginParam
@@ -41,13 +67,62 @@ func (s *AuthUseCaseTestSuite) TestLogin() {
 		wantErrMsg string
 	}{
 		{
-			name: "error - invalid username password",
+			name: "error - user not found",
 			args: args{
 				ctx:    context.TODO(),
 				params: model.LoginParam{},
 			},
 			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:").
+					Return(nil, nil).Once()
+				s.UserMongoRepository.EXPECT().GetByUsername(mock.Anything, mock.Anything).
+					Return(nil, mongo.ErrNoDocuments).Once()
+				s.LoginAttemptRepository.EXPECT().RecordFailure(mock.Anything, "user:", mock.Anything, s.Config.LoginLockout).
+					Return(&model.LoginAttempt{Failures: 1}, nil).Once()
+			},
+			afterTest: func() {
 
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid username or password",
+		},
+		{
+			name: "error - get user",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username: "admin",
+					Password: "password",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, nil).Once()
+				s.UserMongoRepository.EXPECT().GetByUsername(mock.Anything, mock.Anything).
+					Return(nil, errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "error - wrong password",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username: "admin",
+					Password: "wrong",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, nil).Once()
+				s.UserMongoRepository.EXPECT().GetByUsername(mock.Anything, "admin").
+					Return(user, nil).Once()
+				s.LoginAttemptRepository.EXPECT().RecordFailure(mock.Anything, "user:admin", mock.Anything, s.Config.LoginLockout).
+					Return(&model.LoginAttempt{Failures: 1}, nil).Once()
 			},
 			afterTest: func() {
 
@@ -56,16 +131,180 @@ func (s *AuthUseCaseTestSuite) TestLogin() {
 			wantErrMsg: "invalid username or password",
 		},
 		{
-			name: "success",
+			name: "error - repeated failure backs off",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username: "admin",
+					Password: "wrong",
+					IP:       "127.0.0.1",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "ip:127.0.0.1").
+					Return(nil, nil).Once()
+				s.UserMongoRepository.EXPECT().GetByUsername(mock.Anything, "admin").
+					Return(user, nil).Once()
+				s.LoginAttemptRepository.EXPECT().RecordFailure(mock.Anything, "user:admin", mock.Anything, s.Config.LoginLockout).
+					Return(&model.LoginAttempt{Failures: 3}, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Lock(mock.Anything, "user:admin", mock.MatchedBy(func(until time.Time) bool {
+					delay := time.Until(until)
+					return delay > s.Config.LoginBackoff && delay <= 2*s.Config.LoginBackoff
+				})).
+					Return(nil).Once()
+				// addresses are not slowed down before their limit
+				s.LoginAttemptRepository.EXPECT().RecordFailure(mock.Anything, "ip:127.0.0.1", mock.Anything, s.Config.LoginLockout).
+					Return(&model.LoginAttempt{Failures: 3}, nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid username or password",
+		},
+		{
+			name: "error - limit reached locks out",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username: "admin",
+					Password: "wrong",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, nil).Once()
+				s.UserMongoRepository.EXPECT().GetByUsername(mock.Anything, "admin").
+					Return(user, nil).Once()
+				s.LoginAttemptRepository.EXPECT().RecordFailure(mock.Anything, "user:admin", mock.Anything, s.Config.LoginLockout).
+					Return(&model.LoginAttempt{Failures: s.Config.LoginMaxAttempts}, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Lock(mock.Anything, "user:admin", mock.MatchedBy(func(until time.Time) bool {
+					return time.Until(until) > s.Config.LoginLockout-time.Minute
+				})).
+					Return(nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid username or password",
+		},
+		{
+			name: "error - username locked",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username: "admin",
+					Password: "password",
+					IP:       "127.0.0.1",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(&model.LoginAttempt{Failures: 5, LockedUntil: &lockedUntil}, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "ip:127.0.0.1").
+					Return(nil, nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "too many failed login attempts, try again later",
+		},
+		{
+			name: "error - address locked",
 			args: args{
 				ctx: context.TODO(),
 				params: model.LoginParam{
 					Username: "admin",
 					Password: "password",
+					IP:       "127.0.0.1",
 				},
 			},
 			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "ip:127.0.0.1").
+					Return(&model.LoginAttempt{Failures: 20, LockedUntil: &lockedUntil}, nil).Once()
+			},
+			afterTest: func() {
 
+			},
+			wantErr:    true,
+			wantErrMsg: "too many failed login attempts, try again later",
+		},
+		{
+			name: "error - get login attempts",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username: "admin",
+					Password: "password",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "error - store refresh token",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username: "admin",
+					Password: "password",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, nil).Once()
+				s.UserMongoRepository.EXPECT().GetByUsername(mock.Anything, "admin").
+					Return(user, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Reset(mock.Anything, "user:admin").
+					Return(nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().Create(mock.Anything, mock.Anything).
+					Return(nil, errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.TODO(),
+				params: model.LoginParam{
+					Username:  " Admin ",
+					Password:  "password",
+					UserAgent: "curl",
+					IP:        "127.0.0.1",
+				},
+			},
+			mock: func() {
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "user:admin").
+					Return(nil, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Get(mock.Anything, "ip:127.0.0.1").
+					Return(nil, nil).Once()
+				s.UserMongoRepository.EXPECT().GetByUsername(mock.Anything, "admin").
+					Return(user, nil).Once()
+				s.LoginAttemptRepository.EXPECT().Reset(mock.Anything, "user:admin").
+					Return(nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(t *model.RefreshToken) bool {
+					return t.UserID == user.ID.Hex() && t.FamilyID != "" && t.TokenHash != "" && t.UserAgent == "curl" && t.IP == "127.0.0.1"
+				})).
+					RunAndReturn(func(_ context.Context, t *model.RefreshToken) (*model.RefreshToken, error) {
+						return t, nil
+					}).Once()
 			},
 			afterTest: func() {
 
@@ -88,3 +327,370 @@ func (s *AuthUseCaseTestSuite) TestLogin() {
 		})
 	}
 }
+
+func (s *AuthUseCaseTestSuite) TestRegister() {
+	type args struct {
+		ctx    context.Context
+		params model.RegisterParam
+	}
+	tests := []struct {
+		name       string
+		args       args
+		mock       func()
+		afterTest  func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "error - password longer than 72 bytes",
+			args: args{
+				ctx: context.TODO(),
+				params: model.RegisterParam{
+					Username: "admin",
+					Password: strings.Repeat("é", 40),
+				},
+			},
+			mock: func() {},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "password must be at most 72 bytes",
+		},
+		{
+			name: "error - username taken",
+			args: args{
+				ctx: context.TODO(),
+				params: model.RegisterParam{
+					Username: "admin",
+					Password: "password",
+				},
+			},
+			mock: func() {
+				s.UserMongoRepository.EXPECT().Create(mock.Anything, mock.Anything).
+					Return(nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "username already taken",
+		},
+		{
+			name: "error - create",
+			args: args{
+				ctx: context.TODO(),
+				params: model.RegisterParam{
+					Username: "admin",
+					Password: "password",
+				},
+			},
+			mock: func() {
+				s.UserMongoRepository.EXPECT().Create(mock.Anything, mock.Anything).
+					Return(nil, errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.TODO(),
+				params: model.RegisterParam{
+					Username: "Admin",
+					Password: "password",
+				},
+			},
+			mock: func() {
+				s.UserMongoRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u *model.User) bool {
+					return u.Username == "admin" && bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte("password")) == nil
+				})).
+					Return(&model.User{
+						ID:        primitive.NewObjectID(),
+						Username:  "admin",
+						CreatedAt: time.Now(),
+					}, nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			_, err := s.UseCase.Register(tt.args.ctx, tt.args.params)
+			tt.afterTest()
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUseCaseTestSuite) TestRefresh() {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+	user := &model.User{
+		ID:       primitive.NewObjectID(),
+		Username: "admin",
+		Role:     model.RoleAdmin,
+	}
+	stored := func(mutate func(t *model.RefreshToken)) *model.RefreshToken {
+		t := &model.RefreshToken{
+			ID:        primitive.NewObjectID(),
+			UserID:    user.ID.Hex(),
+			FamilyID:  "family-1",
+			ExpiresAt: future,
+		}
+		mutate(t)
+		return t
+	}
+
+	type args struct {
+		ctx    context.Context
+		params model.RefreshTokenParam
+	}
+	tests := []struct {
+		name       string
+		args       args
+		mock       func()
+		afterTest  func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "error - unknown token",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "error - expired token",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.RefreshToken) { t.ExpiresAt = past }), nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "error - revoked token",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.RefreshToken) { t.RevokedAt = &past }), nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "error - reused token revokes family",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.RefreshToken) { t.UsedAt = &past }), nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().RevokeFamily(mock.Anything, "family-1").
+					Return(nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "refresh token reuse detected",
+		},
+		{
+			name: "error - concurrent reuse revokes family",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.RefreshToken) {}), nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().MarkUsed(mock.Anything, mock.Anything).
+					Return(mongo.ErrNoDocuments).Once()
+				s.RefreshTokenMongoRepository.EXPECT().RevokeFamily(mock.Anything, "family-1").
+					Return(nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "refresh token reuse detected",
+		},
+		{
+			name: "error - user removed",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.RefreshToken) {}), nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().MarkUsed(mock.Anything, mock.Anything).
+					Return(nil).Once()
+				s.UserMongoRepository.EXPECT().GetByID(mock.Anything, user.ID.Hex()).
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid refresh token",
+		},
+		{
+			name: "success",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(stored(func(t *model.RefreshToken) {}), nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().MarkUsed(mock.Anything, mock.Anything).
+					Return(nil).Once()
+				s.UserMongoRepository.EXPECT().GetByID(mock.Anything, user.ID.Hex()).
+					Return(user, nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(t *model.RefreshToken) bool {
+					return t.UserID == user.ID.Hex() && t.FamilyID == "family-1"
+				})).
+					RunAndReturn(func(_ context.Context, t *model.RefreshToken) (*model.RefreshToken, error) {
+						return t, nil
+					}).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			_, err := s.UseCase.Refresh(tt.args.ctx, tt.args.params)
+			tt.afterTest()
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *AuthUseCaseTestSuite) TestLogout() {
+	type args struct {
+		ctx    context.Context
+		params model.RefreshTokenParam
+	}
+	tests := []struct {
+		name       string
+		args       args
+		mock       func()
+		afterTest  func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "success - unknown token",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+		{
+			name: "error - revoke",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(&model.RefreshToken{FamilyID: "family-1"}, nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().RevokeFamily(mock.Anything, "family-1").
+					Return(errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "success",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.RefreshTokenParam{RefreshToken: "xxx"},
+			},
+			mock: func() {
+				s.RefreshTokenMongoRepository.EXPECT().GetByHash(mock.Anything, mock.Anything).
+					Return(&model.RefreshToken{FamilyID: "family-1"}, nil).Once()
+				s.RefreshTokenMongoRepository.EXPECT().RevokeFamily(mock.Anything, "family-1").
+					Return(nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			err := s.UseCase.Logout(tt.args.ctx, tt.args.params)
+			tt.afterTest()
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
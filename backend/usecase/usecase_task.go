@@ -7,24 +7,46 @@ import (
 	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"log"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 )
 
 type TaskUseCase struct {
 	TaskMongoRepository interfaces.TaskMongoRepository
+	TaskEventRepository interfaces.TaskEventRepository
+	workflow            model.TaskWorkflow
 }
 
-func NewTaskUseCase(taskMongoRepository interfaces.TaskMongoRepository) TaskUseCase {
+// NewTaskUseCase enforces workflow on status changes, nil means
+// model.DefaultTaskWorkflow
+func NewTaskUseCase(taskMongoRepository interfaces.TaskMongoRepository, taskEventRepository interfaces.TaskEventRepository, workflow model.TaskWorkflow) TaskUseCase {
+	if workflow == nil {
+		workflow = model.DefaultTaskWorkflow()
+	}
 	return TaskUseCase{
 		TaskMongoRepository: taskMongoRepository,
+		TaskEventRepository: taskEventRepository,
+		workflow:            workflow,
 	}
 }
 
-func (t TaskUseCase) ListTask(ctx context.Context, param model.TaskListParam) (res []model.TaskResponse, size int, err error) {
-	filter := bson.M{}
-	if param.Status != "" {
-		filter = bson.M{
-			"status": param.Status,
-		}
+func (t TaskUseCase) ListTask(ctx context.Context, principal model.Principal, param model.TaskListParam) (res []model.TaskResponse, size int, err error) {
+	search := textSearch(param)
+	sort, err := listSort(param, search)
+	if err != nil {
+		return []model.TaskResponse{}, 0, err
+	}
+	if sort == nil && search != "" {
+		sort = []model.SortKey{{Field: model.SortByRelevance, Order: -1}}
+	}
+
+	filter, err := t.listFilter(ctx, principal, param)
+	if err != nil {
+		return []model.TaskResponse{}, 0, err
 	}
 
 	list, count, err := t.TaskMongoRepository.List(
@@ -32,101 +54,600 @@ func (t TaskUseCase) ListTask(ctx context.Context, param model.TaskListParam) (r
 		filter,
 		int64(param.Page),
 		int64(param.Limit),
-		param.SortBy,
-		param.Order,
-		param.Search)
+		sort,
+		search)
 	if err != nil {
 		return []model.TaskResponse{}, 0, err
 	}
 	size = int(count)
 	for _, v := range list {
-		res = append(res, model.TaskResponse{
-			ID:          v.ID.Hex(),
-			Title:       v.Title,
-			Description: v.Description,
-			Status:      v.Status,
-			CreatedAt:   v.CreatedAt,
-		})
+		res = append(res, taskResponse(v))
 	}
 	return
 }
 
-func (t TaskUseCase) GetTask(ctx context.Context, id string) (res *model.TaskResponse, err error) {
-	data, err := t.TaskMongoRepository.GetByID(ctx, id)
+// ListTaskCursor pages through the list by keyset instead of skip, which stays
+// fast on deep pages and doesn't shift when tasks are added in between. A
+// cursor only continues the sort it was made for. The text score can't be
+// used as a key, so relevance is not available here.
+func (t TaskUseCase) ListTaskCursor(ctx context.Context, principal model.Principal, param model.TaskListParam) (res []model.TaskResponse, next string, err error) {
+	search := textSearch(param)
+	sort, err := listSort(param, search)
+	if err != nil {
+		return nil, "", err
+	}
+	if slices.ContainsFunc(sort, func(key model.SortKey) bool { return key.Field == model.SortByRelevance }) {
+		return nil, "", ErrRelevanceNeedsPage
+	}
+	var after *model.TaskCursor
+	if param.Cursor != "" {
+		cursor, ok := model.DecodeTaskCursor(param.Cursor, sort)
+		if !ok {
+			return nil, "", ErrInvalidCursor
+		}
+		after = &cursor
+	}
+
+	filter, err := t.listFilter(ctx, principal, param)
+	if err != nil {
+		return nil, "", err
+	}
+	// one more than asked tells whether there is a next page
+	list, err := t.TaskMongoRepository.ListAfter(ctx, filter, int64(param.Limit)+1, sort, search, after)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, "", err
+	}
+	if len(list) > int(param.Limit) {
+		list = list[:param.Limit]
+		next = model.NewTaskCursor(list[len(list)-1], sort).Encode()
+	}
+	for _, v := range list {
+		res = append(res, taskResponse(v))
+	}
+	return res, next, nil
+}
+
+// listSort is the sort asked for by param, either as a list of keys or as a
+// single sort_by, nil when it names none. Relevance needs a full-text search
+// to rank by.
+func listSort(param model.TaskListParam, search string) ([]model.SortKey, error) {
+	var sort []model.SortKey
+	switch {
+	case param.Sort != "":
+		// the binding checked it already
+		sort, _ = model.ParseTaskSort(param.Sort)
+	case param.SortBy != "":
+		order := param.Order
+		if order != -1 {
+			order = 1
+		}
+		sort = []model.SortKey{{Field: param.SortBy, Order: order}}
+	}
+	if search == "" && slices.ContainsFunc(sort, func(key model.SortKey) bool { return key.Field == model.SortByRelevance }) {
+		return nil, ErrRelevanceNeedsSearch
+	}
+	return sort, nil
+}
+
+// textSearch is the full-text part of the search, a prefix search is part of
+// the filter instead
+func textSearch(param model.TaskListParam) string {
+	if param.SearchMode == model.SearchModePrefix {
+		return ""
+	}
+	return strings.TrimSpace(param.Search)
+}
+
+// listFilter turns the filters of param into a query, scoped to the caller
+func (t TaskUseCase) listFilter(ctx context.Context, principal model.Principal, param model.TaskListParam) (bson.M, error) {
+	filter := bson.M{}
+	if ownerID := readScope(principal); ownerID != "" {
+		filter["owner_id"] = ownerID
+	}
+	status := bson.M{}
+	if param.Status != "" {
+		status["$eq"] = param.Status
+	}
+	if param.Priority != "" {
+		filter["priority"] = param.Priority
+	}
+	if search := strings.TrimSpace(param.Search); search != "" && param.SearchMode == model.SearchModePrefix {
+		// typed by the user, so metacharacters are escaped
+		filter["title"] = bson.M{"$regex": "^" + regexp.QuoteMeta(search), "$options": "i"}
+	}
+
+	due := bson.M{}
+	if !param.DueAfter.IsZero() {
+		due["$gte"] = param.DueAfter
+	}
+	if !param.DueBefore.IsZero() {
+		due["$lt"] = param.DueBefore
+	}
+	if param.Overdue {
+		if now := time.Now().UTC(); param.DueBefore.IsZero() || now.Before(param.DueBefore) {
+			due["$lt"] = now
+		}
+		status["$nin"] = model.ClosedTaskStatuses
+	}
+	if len(due) > 0 {
+		filter["due_date"] = due
+	}
+	if len(status) > 0 {
+		filter["status"] = status
+	}
+	if param.Blocked {
+		blockers, err := t.TaskMongoRepository.OpenBlockerIDs(ctx, readScope(principal))
+		if err != nil {
+			return nil, err
+		}
+		// $in needs an array even when nothing blocks, then nothing matches
+		filter["blocked_by"] = bson.M{"$in": append([]string{}, blockers...)}
+	}
+	if tags := model.NormalizeTags(strings.Split(param.Tags, ",")); len(tags) > 0 {
+		if param.TagMatch == model.TagMatchAll {
+			filter["tags"] = bson.M{"$all": tags}
+		} else {
+			filter["tags"] = bson.M{"$in": tags}
+		}
+	}
+	if strings.TrimSpace(param.Filter) != "" {
+		// kept apart from the fields above, which it may name too
+		query, err := parseTaskFilter(param.Filter)
+		if err != nil {
 			return nil, err
 		}
-		return &model.TaskResponse{}, err
+		filter["$and"] = []bson.M{query}
 	}
+	return filter, nil
+}
 
-	res = &model.TaskResponse{
-		ID:          data.ID.Hex(),
-		Title:       data.Title,
-		Description: data.Description,
-		Status:      data.Status,
-		CreatedAt:   data.CreatedAt,
+func (t TaskUseCase) GetTask(ctx context.Context, principal model.Principal, id string) (res *model.TaskResponse, err error) {
+	data, err := t.TaskMongoRepository.GetByID(ctx, id, readScope(principal))
+	if err != nil {
+		return nil, notFound(err)
 	}
-	return
+
+	response := taskResponse(data)
+	return &response, nil
 }
 
-func (t TaskUseCase) CreateTask(ctx context.Context, body model.TaskBodyParam) (res *model.TaskResponse, err error) {
+func (t TaskUseCase) CreateTask(ctx context.Context, principal model.Principal, body model.TaskBodyParam) (res *model.TaskResponse, err error) {
 
-	created, err := t.TaskMongoRepository.Create(ctx, &model.Task{
+	task := &model.Task{
+		OwnerID:     principal.UserID,
 		Title:       body.Title,
 		Description: body.Description,
 		Status:      body.Status,
-	})
+		Priority:    priorityOrDefault(body.Priority),
+		DueDate:     body.DueDate,
+		Tags:        model.NormalizeTags(body.Tags),
+	}
+	task.PriorityRank = task.Priority.Rank()
+	if body.Status == model.TaskStatusCompleted {
+		now := time.Now().UTC()
+		task.CompletedAt = &now
+	}
+
+	created, err := t.TaskMongoRepository.Create(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	t.record(ctx, principal, created, model.TaskEventCreated, taskChanges(nil, created))
+
+	response := taskResponse(created)
+	return &response, nil
+}
+
+// UpdateTask replaces every field of the task with body
+func (t TaskUseCase) UpdateTask(ctx context.Context, principal model.Principal, id string, version int64, body model.TaskBodyParam) (res *model.TaskResponse, err error) {
+	current, err := t.current(ctx, principal, id, version)
 	if err != nil {
 		return nil, err
 	}
+	if err := t.checkTransition(current, body.Status); err != nil {
+		return nil, err
+	}
+	if err := t.checkBlockers(ctx, current, body.Status); err != nil {
+		return nil, err
+	}
 
-	res = &model.TaskResponse{
-		ID:          created.ID.Hex(),
-		Title:       created.Title,
-		Description: created.Description,
-		Status:      created.Status,
-		CreatedAt:   created.CreatedAt,
+	set := bson.M{
+		"title":       body.Title,
+		"description": body.Description,
+		"status":      body.Status,
+		"priority":    priorityOrDefault(body.Priority),
+		"due_date":    body.DueDate,
+		"tags":        model.NormalizeTags(body.Tags),
 	}
-	return
+	setCompletedAt(set, current, body.Status)
+	return t.update(ctx, principal, current, version, set)
 }
 
-func (t TaskUseCase) UpdateTask(ctx context.Context, id string, body model.TaskBodyParam) (res *model.TaskResponse, err error) {
-	set := bson.M{}
+// PatchTask applies a merge patch: only the fields in patch change, and null
+// clears description, due_date or tags
+func (t TaskUseCase) PatchTask(ctx context.Context, principal model.Principal, id string, version int64, patch model.TaskPatchParam) (res *model.TaskResponse, err error) {
+	for _, field := range []string{"title", "status", "priority"} {
+		if patch.IsNull(field) {
+			return nil, ErrFieldRequired.Errorf("%s can't be cleared", field)
+		}
+	}
 
-	if body.Title != "" {
-		set["title"] = body.Title
+	set := bson.M{}
+	if patch.Title != nil {
+		set["title"] = *patch.Title
+	}
+	if patch.Description != nil {
+		set["description"] = *patch.Description
+	} else if patch.IsNull("description") {
+		set["description"] = ""
 	}
-	if body.Description != "" {
-		set["description"] = body.Description
+	if patch.Status != nil {
+		set["status"] = *patch.Status
 	}
-	if body.Status != "" {
-		set["status"] = body.Status
+	if patch.Priority != nil {
+		set["priority"] = *patch.Priority
+	}
+	if patch.DueDate != nil || patch.IsNull("due_date") {
+		set["due_date"] = patch.DueDate
+	}
+	if patch.Tags != nil {
+		set["tags"] = model.NormalizeTags(*patch.Tags)
+	} else if patch.IsNull("tags") {
+		set["tags"] = []string(nil)
 	}
 
 	if len(set) == 0 {
-		return &model.TaskResponse{}, errors.New("no update data provided")
+		return nil, ErrNoUpdateData
 	}
 
-	data, err := t.TaskMongoRepository.Update(ctx, id, set)
+	current, err := t.current(ctx, principal, id, version)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if patch.Status != nil {
+		if err := t.checkTransition(current, *patch.Status); err != nil {
+			return nil, err
+		}
+		if err := t.checkBlockers(ctx, current, *patch.Status); err != nil {
 			return nil, err
 		}
-		return &model.TaskResponse{}, err
+		setCompletedAt(set, current, *patch.Status)
 	}
+	return t.update(ctx, principal, current, version, set)
+}
 
-	res = &model.TaskResponse{
-		ID:          data.ID.Hex(),
-		Title:       data.Title,
-		Description: data.Description,
-		Status:      data.Status,
-		CreatedAt:   data.CreatedAt,
+// DeleteTask moves the task to the trash, RestoreTask brings it back until
+// PurgeTrash removes it
+func (t TaskUseCase) DeleteTask(ctx context.Context, principal model.Principal, id string, version int64) (err error) {
+	current, err := t.current(ctx, principal, id, version)
+	if err != nil {
+		return err
 	}
-	return
+
+	err = t.TaskMongoRepository.Delete(ctx, id, ownerScope(principal), version, principal.UserID)
+	if err != nil {
+		return t.writeError(ctx, principal, id, version, err)
+	}
+	t.record(ctx, principal, current, model.TaskEventDeleted, nil)
+	return nil
 }
 
-func (t TaskUseCase) DeleteTask(ctx context.Context, id string) (err error) {
-	err = t.TaskMongoRepository.Delete(ctx, id)
+// ListTrash lists the caller's trashed tasks, most recently deleted first
+func (t TaskUseCase) ListTrash(ctx context.Context, principal model.Principal, param model.TaskTrashParam) (res []model.TaskResponse, size int, err error) {
+	filter := bson.M{"deleted_at": bson.M{"$ne": nil}}
+	if ownerID := readScope(principal); ownerID != "" {
+		filter["owner_id"] = ownerID
+	}
+
+	list, count, err := t.TaskMongoRepository.List(ctx, filter, int64(param.Page), int64(param.Limit), []model.SortKey{{Field: "deleted_at", Order: -1}}, "")
+	if err != nil {
+		return []model.TaskResponse{}, 0, err
+	}
+	size = int(count)
+	for _, v := range list {
+		res = append(res, taskResponse(v))
+	}
 	return
 }
+
+func (t TaskUseCase) RestoreTask(ctx context.Context, principal model.Principal, id string) (res *model.TaskResponse, err error) {
+	data, err := t.TaskMongoRepository.Restore(ctx, id, ownerScope(principal))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	t.record(ctx, principal, data, model.TaskEventRestored, nil)
+
+	response := taskResponse(data)
+	return &response, nil
+}
+
+// TaskHistory lists the audit trail of a task, newest first. It stays
+// readable while the task is in the trash.
+func (t TaskUseCase) TaskHistory(ctx context.Context, principal model.Principal, id string, param model.TaskHistoryParam) (res []model.TaskEvent, size int, err error) {
+	events, count, err := t.TaskEventRepository.ListByTask(ctx, id, readScope(principal), int64(param.Page), int64(param.Limit))
+	if err != nil {
+		return nil, 0, err
+	}
+	// tasks created before the audit trail existed have no events
+	if count == 0 {
+		if _, err := t.TaskMongoRepository.GetByID(ctx, id, readScope(principal)); err != nil {
+			return nil, 0, notFound(err)
+		}
+	}
+
+	res = make([]model.TaskEvent, 0, len(events))
+	for _, e := range events {
+		res = append(res, *e)
+	}
+	return res, int(count), nil
+}
+
+// ListTags lists the tags of the caller's tasks with how many tasks carry
+// each, most used first
+func (t TaskUseCase) ListTags(ctx context.Context, principal model.Principal) (res []model.TagCount, err error) {
+	return t.TaskMongoRepository.Tags(ctx, readScope(principal))
+}
+
+// RenameTag renames a tag on every task of the caller. Each renamed task gets
+// a new version and an updated event in its audit trail.
+func (t TaskUseCase) RenameTag(ctx context.Context, principal model.Principal, from, to string) (res *model.TagRenameResponse, err error) {
+	from, to = model.NormalizeTag(from), model.NormalizeTag(to)
+	res = &model.TagRenameResponse{From: from, To: to}
+	if from == to {
+		return res, nil
+	}
+
+	renamed, err := t.TaskMongoRepository.RenameTag(ctx, ownerScope(principal), from, to)
+	// the tasks renamed before a failure are recorded too
+	for _, before := range renamed {
+		after := *before
+		after.Tags = renameTag(before.Tags, from, to)
+		after.Version++
+		t.record(ctx, principal, &after, model.TaskEventUpdated, taskChanges(before, &after))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(renamed) == 0 {
+		return nil, ErrTagNotFound.Errorf("no task has the tag %s", from)
+	}
+	res.Updated = int64(len(renamed))
+	return res, nil
+}
+
+// renameTag is tags with from renamed to to, the way the repository renames
+// them: a tag that ends up twice keeps its first position
+func renameTag(tags []string, from, to string) []string {
+	res := make([]string, len(tags))
+	for i, tag := range tags {
+		if tag == from {
+			tag = to
+		}
+		res[i] = tag
+	}
+	return model.NormalizeTags(res)
+}
+
+// PurgeTrash removes the tasks that have been in the trash for longer than
+// retention, with their history and the dependencies of other tasks on them
+func (t TaskUseCase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := t.TaskMongoRepository.Purge(ctx, time.Now().UTC().Add(-retention))
+	// the tasks purged before a failure are cleaned up too
+	if len(purged) > 0 {
+		if err := t.TaskMongoRepository.RemoveBlockers(ctx, purged); err != nil {
+			return int64(len(purged)), err
+		}
+		if err := t.TaskEventRepository.DeleteByTasks(ctx, purged); err != nil {
+			return int64(len(purged)), err
+		}
+	}
+	return int64(len(purged)), err
+}
+
+// RunTrashPurge calls PurgeTrash every interval until ctx is done. A retention
+// or interval of 0 turns the purge off.
+func (t TaskUseCase) RunTrashPurge(ctx context.Context, retention, interval time.Duration) {
+	if retention <= 0 || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := t.PurgeTrash(ctx, retention)
+			if err != nil {
+				log.Printf("purge trash: %v", err)
+			} else if purged > 0 {
+				log.Printf("purged %d tasks from the trash", purged)
+			}
+		}
+	}
+}
+
+// current loads the task and checks that the client still has its version
+func (t TaskUseCase) current(ctx context.Context, principal model.Principal, id string, version int64) (*model.Task, error) {
+	current, err := t.TaskMongoRepository.GetByID(ctx, id, ownerScope(principal))
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if version != 0 && current.Version != version {
+		return nil, ErrVersionMismatch
+	}
+	return current, nil
+}
+
+// checkTransition fails when current can't move to status under the workflow
+func (t TaskUseCase) checkTransition(current *model.Task, status model.TaskStatus) error {
+	if !t.workflow.Allows(current.Status, status) {
+		return ErrInvalidStatusTransition.Errorf("a task can't move from %s to %s", current.Status, status)
+	}
+	return nil
+}
+
+// setCompletedAt stamps completed_at when current becomes completed and
+// clears it when current is reopened
+func setCompletedAt(set bson.M, current *model.Task, status model.TaskStatus) {
+	switch {
+	case status == model.TaskStatusCompleted && current.Status != model.TaskStatusCompleted:
+		set["completed_at"] = time.Now().UTC()
+	case status != model.TaskStatusCompleted && current.Status == model.TaskStatusCompleted:
+		set["completed_at"] = nil
+	}
+}
+
+func priorityOrDefault(priority model.TaskPriority) model.TaskPriority {
+	if priority == "" {
+		return model.DefaultTaskPriority
+	}
+	return priority
+}
+
+// update writes set only if the task is still current, so the checks made on
+// current and the recorded diff hold. When the client didn't send a version,
+// losing that race is a plain conflict.
+func (t TaskUseCase) update(ctx context.Context, principal model.Principal, current *model.Task, version int64, set bson.M) (*model.TaskResponse, error) {
+	if priority, ok := set["priority"].(model.TaskPriority); ok {
+		// keeps the rank that priority sorts by in step
+		set["priority_rank"] = priority.Rank()
+	}
+	id := current.ID.Hex()
+	data, err := t.TaskMongoRepository.Update(ctx, id, ownerScope(principal), current.Version, set)
+	if err != nil {
+		err = t.writeError(ctx, principal, id, current.Version, err)
+		if version == 0 && errors.Is(err, ErrVersionMismatch) {
+			return nil, ErrConcurrentUpdate
+		}
+		return nil, err
+	}
+	if changes := taskChanges(current, data); len(changes) > 0 {
+		t.record(ctx, principal, data, model.TaskEventUpdated, changes)
+	}
+
+	response := taskResponse(data)
+	return &response, nil
+}
+
+// writeError tells a task that is gone apart from one that moved past version
+func (t TaskUseCase) writeError(ctx context.Context, principal model.Principal, id string, version int64, err error) error {
+	if version == 0 || !errors.Is(err, mongo.ErrNoDocuments) {
+		return notFound(err)
+	}
+	if _, err := t.TaskMongoRepository.GetByID(ctx, id, ownerScope(principal)); err != nil {
+		return notFound(err)
+	}
+	return ErrVersionMismatch
+}
+
+// record appends an event to the audit trail of task. The task is already
+// written by then, so a failing insert is logged instead of failing the request.
+func (t TaskUseCase) record(ctx context.Context, principal model.Principal, task *model.Task, action model.TaskEventAction, changes []model.FieldChange) {
+	err := t.TaskEventRepository.Create(ctx, &model.TaskEvent{
+		TaskID:  task.ID.Hex(),
+		OwnerID: task.OwnerID,
+		Action:  action,
+		ActorID: principal.UserID,
+		Changes: changes,
+	})
+	if err != nil {
+		log.Printf("record %s event of task %s: %v", action, task.ID.Hex(), err)
+	}
+}
+
+// taskChanges is the field-level diff between two states of a task. before is
+// nil for a new task, whose empty fields are left out.
+func taskChanges(before, after *model.Task) []model.FieldChange {
+	var old model.Task
+	if before != nil {
+		old = *before
+	}
+	fields := []struct {
+		name          string
+		before, after any
+	}{
+		{"title", old.Title, after.Title},
+		{"description", old.Description, after.Description},
+		{"status", string(old.Status), string(after.Status)},
+		{"priority", string(old.Priority), string(after.Priority)},
+		{"due_date", timeValue(old.DueDate), timeValue(after.DueDate)},
+		{"completed_at", timeValue(old.CompletedAt), timeValue(after.CompletedAt)},
+		{"tags", stringsValue(old.Tags), stringsValue(after.Tags)},
+		{"checklist", checklistValue(old.Checklist), checklistValue(after.Checklist)},
+		{"blocked_by", stringsValue(old.BlockedBy), stringsValue(after.BlockedBy)},
+	}
+
+	var changes []model.FieldChange
+	for _, f := range fields {
+		if reflect.DeepEqual(f.before, f.after) {
+			continue
+		}
+		change := model.FieldChange{Field: f.name, Before: f.before, After: f.after}
+		if before == nil {
+			change.Before = nil
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// timeValue is how a time shows up in a diff, nil when it isn't set
+func timeValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// stringsValue is how a list of strings shows up in a diff, nil when it is empty
+func stringsValue(values []string) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// checklistValue is how a checklist shows up in a diff, nil when it is empty
+func checklistValue(items []model.ChecklistItem) any {
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
+
+func taskResponse(t *model.Task) model.TaskResponse {
+	return model.TaskResponse{
+		ID:          t.ID.Hex(),
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueDate:     t.DueDate,
+		CompletedAt: t.CompletedAt,
+		Tags:        t.Tags,
+		Checklist:   t.Checklist,
+		Progress:    checklistProgress(t.Checklist),
+		BlockedBy:   t.BlockedBy,
+		Version:     t.Version,
+		CreatedAt:   t.CreatedAt,
+		DeletedAt:   t.DeletedAt,
+		DeletedBy:   t.DeletedBy,
+	}
+}
+
+// ownerScope is the owner filter for the caller, admins are not restricted
+func ownerScope(principal model.Principal) string {
+	if principal.CanManageAll() {
+		return ""
+	}
+	return principal.UserID
+}
+
+// readScope is the owner filter for reads, viewers and admins read every task
+func readScope(principal model.Principal) string {
+	if principal.CanReadAll() {
+		return ""
+	}
+	return principal.UserID
+}
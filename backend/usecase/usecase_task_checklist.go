@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddChecklistItem appends an item to the checklist of the task
+func (t TaskUseCase) AddChecklistItem(ctx context.Context, principal model.Principal, id string, version int64, body model.ChecklistItemBodyParam) (res *model.TaskResponse, err error) {
+	return t.editChecklist(ctx, principal, id, version, func(items []model.ChecklistItem) ([]model.ChecklistItem, error) {
+		if len(items) >= model.MaxChecklistItems {
+			return nil, ErrChecklistFull
+		}
+		return append(items, model.ChecklistItem{
+			ID:   primitive.NewObjectID(),
+			Text: body.Text,
+			Done: body.Done,
+		}), nil
+	})
+}
+
+// UpdateChecklistItem changes the text of an item or ticks it off
+func (t TaskUseCase) UpdateChecklistItem(ctx context.Context, principal model.Principal, id, itemID string, version int64, patch model.ChecklistItemPatchParam) (res *model.TaskResponse, err error) {
+	if patch.Text == nil && patch.Done == nil {
+		return nil, ErrNoUpdateData
+	}
+	return t.editChecklist(ctx, principal, id, version, func(items []model.ChecklistItem) ([]model.ChecklistItem, error) {
+		i := checklistIndex(items, itemID)
+		if i < 0 {
+			return nil, ErrChecklistItemNotFound
+		}
+		if patch.Text != nil {
+			items[i].Text = *patch.Text
+		}
+		if patch.Done != nil {
+			items[i].Done = *patch.Done
+		}
+		return items, nil
+	})
+}
+
+// ReorderChecklist puts the items in the order of ids, which must name every
+// item of the checklist once
+func (t TaskUseCase) ReorderChecklist(ctx context.Context, principal model.Principal, id string, version int64, ids []string) (res *model.TaskResponse, err error) {
+	return t.editChecklist(ctx, principal, id, version, func(items []model.ChecklistItem) ([]model.ChecklistItem, error) {
+		if len(ids) != len(items) {
+			return nil, ErrInvalidChecklistOrder
+		}
+		ordered := make([]model.ChecklistItem, 0, len(items))
+		seen := make(map[string]bool, len(ids))
+		for _, itemID := range ids {
+			i := checklistIndex(items, itemID)
+			if i < 0 || seen[itemID] {
+				return nil, ErrInvalidChecklistOrder
+			}
+			seen[itemID] = true
+			ordered = append(ordered, items[i])
+		}
+		return ordered, nil
+	})
+}
+
+func (t TaskUseCase) RemoveChecklistItem(ctx context.Context, principal model.Principal, id, itemID string, version int64) (res *model.TaskResponse, err error) {
+	return t.editChecklist(ctx, principal, id, version, func(items []model.ChecklistItem) ([]model.ChecklistItem, error) {
+		i := checklistIndex(items, itemID)
+		if i < 0 {
+			return nil, ErrChecklistItemNotFound
+		}
+		return append(items[:i], items[i+1:]...), nil
+	})
+}
+
+// editChecklist writes the checklist that edit makes out of a copy of the
+// current one, through update so it is versioned and audited like any other
+// field
+func (t TaskUseCase) editChecklist(ctx context.Context, principal model.Principal, id string, version int64, edit func([]model.ChecklistItem) ([]model.ChecklistItem, error)) (*model.TaskResponse, error) {
+	current, err := t.current(ctx, principal, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := edit(append([]model.ChecklistItem(nil), current.Checklist...))
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		items = nil
+	}
+	return t.update(ctx, principal, current, version, bson.M{"checklist": items})
+}
+
+// checklistIndex is the position of the item with the hex id itemID, -1 if
+// there is none
+func checklistIndex(items []model.ChecklistItem, itemID string) int {
+	for i, item := range items {
+		if item.ID.Hex() == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// checklistProgress counts the done items, nil when there are none
+func checklistProgress(items []model.ChecklistItem) *model.TaskProgress {
+	if len(items) == 0 {
+		return nil
+	}
+	progress := &model.TaskProgress{Total: len(items)}
+	for _, item := range items {
+		if item.Done {
+			progress.Done++
+		}
+	}
+	return progress
+}
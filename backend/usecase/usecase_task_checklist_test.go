@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (s *TaskUseCaseTestSuite) TestChecklist() {
+	taskID, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7ab")
+	first, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7b1")
+	second, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7b2")
+	stored := func() *model.Task {
+		return &model.Task{ID: taskID, OwnerID: "user-1", Status: model.TaskStatusTodo, Version: 2, Checklist: []model.ChecklistItem{
+			{ID: first, Text: "write", Done: true},
+			{ID: second, Text: "review"},
+		}}
+	}
+	text := "proofread"
+	done := true
+
+	tests := []struct {
+		name       string
+		call       func() (*model.TaskResponse, error)
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+		want       *model.TaskProgress
+	}{
+		{
+			name: "error - task not found",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.AddChecklistItem(context.TODO(), member, taskID.Hex(), 0, model.ChecklistItemBodyParam{Text: "test"})
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskID.Hex(), "user-1").
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "data not found",
+		},
+		{
+			name: "success - add",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.AddChecklistItem(context.TODO(), member, taskID.Hex(), 2, model.ChecklistItemBodyParam{Text: "test"})
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskID.Hex(), "user-1").Return(stored(), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, taskID.Hex(), "user-1", int64(2), mock.MatchedBy(func(set bson.M) bool {
+					items := set["checklist"].([]model.ChecklistItem)
+					return len(items) == 3 && items[2].Text == "test" && !items[2].ID.IsZero()
+				})).Return(&model.Task{ID: taskID, OwnerID: "user-1", Version: 3, Checklist: append(stored().Checklist, model.ChecklistItem{Text: "test"})}, nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: &model.TaskProgress{Done: 1, Total: 3},
+		},
+		{
+			name: "error - update unknown item",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.UpdateChecklistItem(context.TODO(), member, taskID.Hex(), "68fc6a818c54acf4a737d7ff", 0, model.ChecklistItemPatchParam{Done: &done})
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskID.Hex(), "user-1").Return(stored(), nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "checklist item not found",
+		},
+		{
+			name: "error - update without data",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.UpdateChecklistItem(context.TODO(), member, taskID.Hex(), second.Hex(), 0, model.ChecklistItemPatchParam{})
+			},
+			mock:       func() {},
+			wantErr:    true,
+			wantErrMsg: "no update data provided",
+		},
+		{
+			name: "success - toggle and rename",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.UpdateChecklistItem(context.TODO(), member, taskID.Hex(), second.Hex(), 0, model.ChecklistItemPatchParam{Text: &text, Done: &done})
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskID.Hex(), "user-1").Return(stored(), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, taskID.Hex(), "user-1", int64(2), bson.M{"checklist": []model.ChecklistItem{
+					{ID: first, Text: "write", Done: true},
+					{ID: second, Text: "proofread", Done: true},
+				}}).Return(&model.Task{ID: taskID, OwnerID: "user-1", Version: 3, Checklist: []model.ChecklistItem{
+					{ID: first, Text: "write", Done: true},
+					{ID: second, Text: "proofread", Done: true},
+				}}, nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: &model.TaskProgress{Done: 2, Total: 2},
+		},
+		{
+			name: "error - reorder with a missing item",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.ReorderChecklist(context.TODO(), member, taskID.Hex(), 0, []string{second.Hex(), second.Hex()})
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskID.Hex(), "user-1").Return(stored(), nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "ids must list every checklist item once",
+		},
+		{
+			name: "success - reorder",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.ReorderChecklist(context.TODO(), member, taskID.Hex(), 0, []string{second.Hex(), first.Hex()})
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskID.Hex(), "user-1").Return(stored(), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, taskID.Hex(), "user-1", int64(2), bson.M{"checklist": []model.ChecklistItem{
+					{ID: second, Text: "review"},
+					{ID: first, Text: "write", Done: true},
+				}}).Return(&model.Task{ID: taskID, OwnerID: "user-1", Version: 3, Checklist: []model.ChecklistItem{
+					{ID: second, Text: "review"},
+					{ID: first, Text: "write", Done: true},
+				}}, nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: &model.TaskProgress{Done: 1, Total: 2},
+		},
+		{
+			name: "success - remove the last items",
+			call: func() (*model.TaskResponse, error) {
+				return s.UseCase.RemoveChecklistItem(context.TODO(), member, taskID.Hex(), first.Hex(), 0)
+			},
+			mock: func() {
+				task := stored()
+				task.Checklist = task.Checklist[:1]
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskID.Hex(), "user-1").Return(task, nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, taskID.Hex(), "user-1", int64(2), bson.M{"checklist": []model.ChecklistItem(nil)}).
+					Return(&model.Task{ID: taskID, OwnerID: "user-1", Version: 3}, nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+			res, err := tt.call()
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+				return
+			}
+			s.NoError(err)
+			s.Equal(tt.want, res.Progress)
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestChecklistFull() {
+	task := &model.Task{ID: primitive.NewObjectID(), OwnerID: "user-1", Version: 1}
+	for range model.MaxChecklistItems {
+		task.Checklist = append(task.Checklist, model.ChecklistItem{ID: primitive.NewObjectID(), Text: "step"})
+	}
+	s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, task.ID.Hex(), "user-1").Return(task, nil).Once()
+
+	_, err := s.UseCase.AddChecklistItem(context.TODO(), member, task.ID.Hex(), 0, model.ChecklistItemBodyParam{Text: "one more"})
+	s.ErrorIs(err, usecase.ErrChecklistFull)
+}
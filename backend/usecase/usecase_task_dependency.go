@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"slices"
+	"strings"
+)
+
+// AddDependency marks the task as blocked by blockerID, another live task of
+// the same owner. Adding a dependency that exists already changes nothing.
+func (t TaskUseCase) AddDependency(ctx context.Context, principal model.Principal, id string, version int64, blockerID string) (res *model.TaskResponse, err error) {
+	if blockerID == id {
+		return nil, ErrDependencyCycle.Errorf("a task can't block itself")
+	}
+	current, err := t.current(ctx, principal, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(current.BlockedBy, blockerID) {
+		response := taskResponse(current)
+		return &response, nil
+	}
+	if len(current.BlockedBy) >= model.MaxTaskBlockers {
+		return nil, ErrTooManyBlockers
+	}
+
+	if _, err := t.TaskMongoRepository.GetByID(ctx, blockerID, current.OwnerID); err != nil {
+		if err = notFound(err); errors.Is(err, ErrNotFound) {
+			return nil, ErrNotFound.Errorf("blocking task not found")
+		}
+		return nil, err
+	}
+	walked, err := t.checkCycle(ctx, current, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	// the walk and the write are separate steps, so a dependency added in
+	// between could still close a cycle. Each walked task gets a new version
+	// if it is unchanged and the task is written at the version it was read
+	// at, so of two requests that race on the same tasks the later one fails.
+	bumped, err := t.TaskMongoRepository.BumpVersions(ctx, walked)
+	if err != nil {
+		return nil, err
+	}
+	if bumped < int64(len(walked)) {
+		return nil, ErrConcurrentUpdate
+	}
+
+	blockedBy := append(slices.Clone(current.BlockedBy), blockerID)
+	return t.update(ctx, principal, current, version, bson.M{"blocked_by": blockedBy})
+}
+
+// RemoveDependency lifts a dependency, the blocking task doesn't have to exist
+// anymore
+func (t TaskUseCase) RemoveDependency(ctx context.Context, principal model.Principal, id, blockerID string, version int64) (res *model.TaskResponse, err error) {
+	current, err := t.current(ctx, principal, id, version)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.Index(current.BlockedBy, blockerID)
+	if i < 0 {
+		return nil, ErrDependencyNotFound
+	}
+
+	blockedBy := slices.Delete(slices.Clone(current.BlockedBy), i, i+1)
+	if len(blockedBy) == 0 {
+		blockedBy = nil
+	}
+	return t.update(ctx, principal, current, version, bson.M{"blocked_by": blockedBy})
+}
+
+// checkCycle walks what blockerID waits for, breadth first and one query per
+// level, and fails if task is among it: task would end up waiting for itself.
+// Trashed tasks are walked too, as restoring them brings their dependencies
+// back. It returns the walked tasks as they were read.
+func (t TaskUseCase) checkCycle(ctx context.Context, task *model.Task, blockerID string) ([]*model.Task, error) {
+	target := task.ID.Hex()
+	seen := map[string]bool{blockerID: true}
+	var walked []*model.Task
+	for level := []string{blockerID}; len(level) > 0; {
+		tasks, err := t.TaskMongoRepository.ListByIDs(ctx, level, task.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		walked = append(walked, tasks...)
+		level = nil
+		for _, blocker := range tasks {
+			for _, next := range blocker.BlockedBy {
+				if next == target {
+					return nil, ErrDependencyCycle.Errorf("task %s already waits for this task", blockerID)
+				}
+				if !seen[next] {
+					seen[next] = true
+					level = append(level, next)
+				}
+			}
+		}
+	}
+	return walked, nil
+}
+
+// checkBlockers fails when current is about to be completed while some of
+// its blockers are open. Blockers that are closed, trashed or gone don't block.
+func (t TaskUseCase) checkBlockers(ctx context.Context, current *model.Task, status model.TaskStatus) error {
+	if status != model.TaskStatusCompleted || current.Status == model.TaskStatusCompleted || len(current.BlockedBy) == 0 {
+		return nil
+	}
+	blockers, err := t.TaskMongoRepository.ListByIDs(ctx, current.BlockedBy, current.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	var open []string
+	for _, blocker := range blockers {
+		if blocker.DeletedAt == nil && !slices.Contains(model.ClosedTaskStatuses, blocker.Status) {
+			open = append(open, blocker.ID.Hex())
+		}
+	}
+	if len(open) > 0 {
+		return ErrTaskBlocked.Errorf("task is blocked by open tasks %s", strings.Join(open, ", "))
+	}
+	return nil
+}
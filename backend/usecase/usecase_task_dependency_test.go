@@ -0,0 +1,197 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+const (
+	taskA = "68fc6a818c54acf4a737d7a1"
+	taskB = "68fc6a818c54acf4a737d7b2"
+	taskC = "68fc6a818c54acf4a737d7c3"
+)
+
+func dependencyTask(id string, status model.TaskStatus, blockedBy ...string) *model.Task {
+	oid, _ := primitive.ObjectIDFromHex(id)
+	return &model.Task{ID: oid, OwnerID: "user-1", Status: status, Version: 1, BlockedBy: blockedBy}
+}
+
+func (s *TaskUseCaseTestSuite) TestAddDependency() {
+	tests := []struct {
+		name       string
+		blockerID  string
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:       "error - blocks itself",
+			blockerID:  taskA,
+			mock:       func() {},
+			wantErr:    true,
+			wantErrMsg: "a task can't block itself",
+		},
+		{
+			name:      "error - blocker of another owner",
+			blockerID: taskB,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+					Return(dependencyTask(taskA, model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskB, "user-1").
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "blocking task not found",
+		},
+		{
+			name:      "error - cycle two levels away",
+			blockerID: taskB,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+					Return(dependencyTask(taskA, model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskB, "user-1").
+					Return(dependencyTask(taskB, model.TaskStatusTodo, taskC), nil).Once()
+				s.TaskMongoRepository.EXPECT().ListByIDs(mock.Anything, []string{taskB}, "user-1").
+					Return([]*model.Task{dependencyTask(taskB, model.TaskStatusTodo, taskC)}, nil).Once()
+				s.TaskMongoRepository.EXPECT().ListByIDs(mock.Anything, []string{taskC}, "user-1").
+					Return([]*model.Task{dependencyTask(taskC, model.TaskStatusTodo, taskA)}, nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "task " + taskB + " already waits for this task",
+		},
+		{
+			name:      "error - a walked task changed after the check",
+			blockerID: taskB,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+					Return(dependencyTask(taskA, model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskB, "user-1").
+					Return(dependencyTask(taskB, model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().ListByIDs(mock.Anything, []string{taskB}, "user-1").
+					Return([]*model.Task{dependencyTask(taskB, model.TaskStatusTodo)}, nil).Once()
+				// a concurrent request made taskB wait for taskA in the meantime
+				s.TaskMongoRepository.EXPECT().BumpVersions(mock.Anything, []*model.Task{dependencyTask(taskB, model.TaskStatusTodo)}).
+					Return(0, nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "task was changed by another request, try again",
+		},
+		{
+			name:      "success - already blocked",
+			blockerID: taskB,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+					Return(dependencyTask(taskA, model.TaskStatusTodo, taskB), nil).Once()
+			},
+		},
+		{
+			name:      "success",
+			blockerID: taskB,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+					Return(dependencyTask(taskA, model.TaskStatusTodo, taskC), nil).Once()
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskB, "user-1").
+					Return(dependencyTask(taskB, model.TaskStatusTodo, taskC), nil).Once()
+				s.TaskMongoRepository.EXPECT().ListByIDs(mock.Anything, []string{taskB}, "user-1").
+					Return([]*model.Task{dependencyTask(taskB, model.TaskStatusTodo, taskC)}, nil).Once()
+				s.TaskMongoRepository.EXPECT().ListByIDs(mock.Anything, []string{taskC}, "user-1").
+					Return([]*model.Task{dependencyTask(taskC, model.TaskStatusTodo)}, nil).Once()
+				s.TaskMongoRepository.EXPECT().BumpVersions(mock.Anything, []*model.Task{
+					dependencyTask(taskB, model.TaskStatusTodo, taskC),
+					dependencyTask(taskC, model.TaskStatusTodo),
+				}).Return(2, nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, taskA, "user-1", int64(1), bson.M{"blocked_by": []string{taskC, taskB}}).
+					Return(dependencyTask(taskA, model.TaskStatusTodo, taskC, taskB), nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+			res, err := s.UseCase.AddDependency(context.TODO(), member, taskA, 0, tt.blockerID)
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+				return
+			}
+			s.NoError(err)
+			s.Contains(res.BlockedBy, tt.blockerID)
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestRemoveDependency() {
+	s.Run("error - not blocked by it", func() {
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+			Return(dependencyTask(taskA, model.TaskStatusTodo, taskC), nil).Once()
+
+		_, err := s.UseCase.RemoveDependency(context.TODO(), member, taskA, taskB, 0)
+		s.EqualError(err, "task is not blocked by this task")
+	})
+
+	s.Run("success - last dependency", func() {
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+			Return(dependencyTask(taskA, model.TaskStatusTodo, taskB), nil).Once()
+		s.TaskMongoRepository.EXPECT().Update(mock.Anything, taskA, "user-1", int64(1), bson.M{"blocked_by": []string(nil)}).
+			Return(dependencyTask(taskA, model.TaskStatusTodo), nil).Once()
+		s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		res, err := s.UseCase.RemoveDependency(context.TODO(), member, taskA, taskB, 0)
+		s.NoError(err)
+		s.Empty(res.BlockedBy)
+	})
+}
+
+func (s *TaskUseCaseTestSuite) TestCompleteBlockedTask() {
+	completed := model.TaskStatusCompleted
+	trashed := dependencyTask(taskC, model.TaskStatusTodo)
+	trashed.DeletedAt = &time.Time{}
+
+	s.Run("error - open blocker", func() {
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+			Return(dependencyTask(taskA, model.TaskStatusInProgress, taskB, taskC), nil).Once()
+		s.TaskMongoRepository.EXPECT().ListByIDs(mock.Anything, []string{taskB, taskC}, "user-1").
+			Return([]*model.Task{dependencyTask(taskB, model.TaskStatusInProgress), trashed}, nil).Once()
+
+		_, err := s.UseCase.PatchTask(context.TODO(), member, taskA, 0, model.TaskPatchParam{Status: &completed})
+		s.EqualError(err, "task is blocked by open tasks "+taskB)
+	})
+
+	s.Run("success - blockers closed or trashed", func() {
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, taskA, "user-1").
+			Return(dependencyTask(taskA, model.TaskStatusInProgress, taskB, taskC), nil).Once()
+		s.TaskMongoRepository.EXPECT().ListByIDs(mock.Anything, []string{taskB, taskC}, "user-1").
+			Return([]*model.Task{dependencyTask(taskB, model.TaskStatusCancelled), trashed}, nil).Once()
+		s.TaskMongoRepository.EXPECT().Update(mock.Anything, taskA, "user-1", int64(1), mock.Anything).
+			Return(dependencyTask(taskA, model.TaskStatusCompleted, taskB, taskC), nil).Once()
+		s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := s.UseCase.PatchTask(context.TODO(), member, taskA, 0, model.TaskPatchParam{Status: &completed})
+		s.NoError(err)
+	})
+}
+
+func (s *TaskUseCaseTestSuite) TestListBlockedTasks() {
+	s.Run("only tasks waiting for open blockers", func() {
+		s.TaskMongoRepository.EXPECT().OpenBlockerIDs(mock.Anything, "user-1").Return([]string{taskB}, nil).Once()
+		s.TaskMongoRepository.EXPECT().List(mock.Anything, bson.M{"owner_id": "user-1", "blocked_by": bson.M{"$in": []string{taskB}}}, int64(1), int64(10), []model.SortKey(nil), "").
+			Return(nil, 0, nil).Once()
+
+		_, _, err := s.UseCase.ListTask(context.TODO(), member, model.TaskListParam{Limit: 10, Page: 1, Blocked: true})
+		s.NoError(err)
+	})
+
+	s.Run("nothing blocks", func() {
+		s.TaskMongoRepository.EXPECT().OpenBlockerIDs(mock.Anything, "user-1").Return(nil, nil).Once()
+		s.TaskMongoRepository.EXPECT().List(mock.Anything, bson.M{"owner_id": "user-1", "blocked_by": bson.M{"$in": []string{}}}, int64(1), int64(10), []model.SortKey(nil), "").
+			Return(nil, 0, nil).Once()
+
+		_, _, err := s.UseCase.ListTask(context.TODO(), member, model.TaskListParam{Limit: 10, Page: 1, Blocked: true})
+		s.NoError(err)
+	})
+}
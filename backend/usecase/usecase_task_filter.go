@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"fmt"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// limits of a filter, so a request can't make the query arbitrarily large
+const (
+	maxFilterConditions = 20
+	maxFilterDepth      = 5
+)
+
+// filterField is a field the filter grammar knows: the document field it
+// matches, the operators it takes and how a value is checked and converted.
+type filterField struct {
+	column string
+	ops    []string
+	value  func(s string) (any, bool)
+	expect string
+}
+
+var taskFilterFields = map[string]filterField{
+	"status": {column: "status", ops: []string{":", "!="}, value: func(s string) (any, bool) {
+		return model.TaskStatus(s), model.TaskStatus(s).Valid()
+	}, expect: "a task status"},
+	"priority": {column: "priority", ops: []string{":", "!="}, value: func(s string) (any, bool) {
+		return model.TaskPriority(s), model.TaskPriority(s).Valid()
+	}, expect: "a task priority"},
+	"tags": {column: "tags", ops: []string{":", "!="}, value: func(s string) (any, bool) {
+		return model.NormalizeTag(s), model.ValidTag(s)
+	}, expect: "a tag"},
+	"created_at":   dateFilterField("created_at"),
+	"updated_at":   dateFilterField("updated_at"),
+	"due_date":     dateFilterField("due_date"),
+	"completed_at": dateFilterField("completed_at"),
+}
+
+func dateFilterField(column string) filterField {
+	return filterField{column: column, ops: []string{">", ">=", "<", "<="}, value: filterDate, expect: "a date or an RFC 3339 time"}
+}
+
+// filterDate reads a date as midnight UTC, or a full RFC 3339 time
+func filterDate(s string) (any, bool) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t.UTC(), err == nil
+}
+
+// mongo operators of the filter operators, ":" is a plain match
+var filterOperators = map[string]string{
+	"!=": "$ne",
+	">":  "$gt",
+	">=": "$gte",
+	"<":  "$lt",
+	"<=": "$lte",
+}
+
+// parseTaskFilter turns a filter like
+//
+//	status:in(backlog,in_progress) AND NOT (tags:wontfix OR created_at<2026-01-01)
+//
+// into a Mongo filter. Conditions are field, operator and value: ":" and
+// "!=" match a value or any of in(a,b,...), ">", ">=", "<" and "<=" compare
+// dates. AND binds tighter than OR, NOT negates what follows it and
+// parentheses group. Keywords are case-insensitive, values with spaces or
+// ",()" are "quoted". Fields and values are checked against the task model,
+// so the result only ever holds whitelisted fields and plain values.
+func parseTaskFilter(s string) (bson.M, error) {
+	p := &filterParser{input: s}
+	filter, err := p.or(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.rest())
+	}
+	return filter, nil
+}
+
+type filterParser struct {
+	input      string
+	pos        int
+	conditions int
+}
+
+func (p *filterParser) errorf(format string, args ...any) error {
+	return ErrInvalidFilter.Errorf("filter: %s at position %d", fmt.Sprintf(format, args...), p.pos+1)
+}
+
+// or := and ("OR" and)*
+func (p *filterParser) or(depth int) (bson.M, error) {
+	return p.list(depth, "OR", "$or", p.and)
+}
+
+// and := not ("AND" not)*
+func (p *filterParser) and(depth int) (bson.M, error) {
+	return p.list(depth, "AND", "$and", p.not)
+}
+
+func (p *filterParser) list(depth int, keyword, op string, next func(int) (bson.M, error)) (bson.M, error) {
+	var terms []bson.M
+	for {
+		term, err := next(depth)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+		if !p.keyword(keyword) {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return bson.M{op: terms}, nil
+}
+
+// not := "NOT" not | "(" or ")" | condition
+//
+// Each NOT and each group is a level of the query, depth counts both.
+func (p *filterParser) not(depth int) (bson.M, error) {
+	if p.keyword("NOT") {
+		if depth == maxFilterDepth {
+			return nil, p.errorf("groups and NOTs nest deeper than %d", maxFilterDepth)
+		}
+		term, err := p.not(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return bson.M{"$nor": []bson.M{term}}, nil
+	}
+	if p.skipSpace(); p.consume("(") {
+		if depth == maxFilterDepth {
+			return nil, p.errorf("groups and NOTs nest deeper than %d", maxFilterDepth)
+		}
+		term, err := p.or(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if p.skipSpace(); !p.consume(")") {
+			return nil, p.errorf("missing )")
+		}
+		return term, nil
+	}
+	return p.condition()
+}
+
+// condition := field op (value | "in(" value ("," value)* ")")
+func (p *filterParser) condition() (bson.M, error) {
+	if p.conditions++; p.conditions > maxFilterConditions {
+		return nil, p.errorf("more than %d conditions", maxFilterConditions)
+	}
+
+	p.skipSpace()
+	start := p.pos
+	p.skipWhile(func(r rune) bool { return r == '_' || unicode.IsLetter(r) })
+	name := p.input[start:p.pos]
+	if name == "" {
+		return nil, p.errorf("expected a field")
+	}
+	field, ok := taskFilterFields[strings.ToLower(name)]
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("unknown field %q", name)
+	}
+
+	p.skipSpace()
+	op := p.operator()
+	if op == "" {
+		return nil, p.errorf("expected an operator after %s", name)
+	}
+	if !slices.Contains(field.ops, op) {
+		p.pos -= len(op)
+		return nil, p.errorf("%s doesn't support the operator %q", name, op)
+	}
+
+	p.skipSpace()
+	if (op == ":" || op == "!=") && strings.HasPrefix(strings.ToLower(p.rest()), "in(") {
+		p.pos += len("in(")
+		values, err := p.values(name, field)
+		if err != nil {
+			return nil, err
+		}
+		in := "$in"
+		if op == "!=" {
+			in = "$nin"
+		}
+		return bson.M{field.column: bson.M{in: values}}, nil
+	}
+
+	value, err := p.value(name, field)
+	if err != nil {
+		return nil, err
+	}
+	if op == ":" {
+		return bson.M{field.column: value}, nil
+	}
+	return bson.M{field.column: bson.M{filterOperators[op]: value}}, nil
+}
+
+// values reads the list of an in( whose "in(" is already consumed
+func (p *filterParser) values(name string, field filterField) ([]any, error) {
+	var values []any
+	for {
+		value, err := p.value(name, field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if p.skipSpace(); p.consume(")") {
+			return values, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or ) in the list of %s", name)
+		}
+	}
+}
+
+func (p *filterParser) value(name string, field filterField) (any, error) {
+	p.skipSpace()
+	start := p.pos
+	raw, err := p.word()
+	if err != nil {
+		return nil, err
+	}
+	value, ok := field.value(raw)
+	if !ok {
+		p.pos = start
+		return nil, p.errorf("%s must be %s, not %q", name, field.expect, raw)
+	}
+	return value, nil
+}
+
+// word is a "quoted" string or a run of characters up to a space, ',', '('
+// or ')'
+func (p *filterParser) word() (string, error) {
+	if p.consume(`"`) {
+		var b strings.Builder
+		for p.pos < len(p.input) {
+			r, size := p.peek()
+			p.pos += size
+			switch {
+			case r == '"':
+				return b.String(), nil
+			case r == '\\' && p.pos < len(p.input):
+				_, size = p.peek()
+				b.WriteString(p.input[p.pos : p.pos+size])
+				p.pos += size
+			default:
+				b.WriteString(p.input[p.pos-size : p.pos])
+			}
+		}
+		return "", p.errorf("unterminated string")
+	}
+	start := p.pos
+	p.skipWhile(func(r rune) bool { return !strings.ContainsRune(" \t\n,()", r) })
+	if p.pos == start {
+		return "", p.errorf("expected a value")
+	}
+	return p.input[start:p.pos], nil
+}
+
+// operator reads the longest operator at the position, empty if there is
+// none. "=" and other operators the grammar doesn't have are read too, so
+// they can be reported as unsupported.
+func (p *filterParser) operator() string {
+	start := p.pos
+	for r, size := p.peek(); size > 0 && strings.ContainsRune(":!=<>~", r); r, size = p.peek() {
+		p.pos += size
+		if p.input[start:p.pos] == ":" {
+			break
+		}
+	}
+	return p.input[start:p.pos]
+}
+
+// keyword consumes the keyword, in any case, when it stands on its own
+func (p *filterParser) keyword(keyword string) bool {
+	p.skipSpace()
+	rest := p.rest()
+	if len(rest) < len(keyword) || !strings.EqualFold(rest[:len(keyword)], keyword) {
+		return false
+	}
+	if next, size := utf8.DecodeRuneInString(rest[len(keyword):]); size > 0 && !strings.ContainsRune(" \t\n(", next) {
+		return false
+	}
+	p.pos += len(keyword)
+	return true
+}
+
+func (p *filterParser) consume(s string) bool {
+	if strings.HasPrefix(p.rest(), s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) skipSpace() {
+	p.skipWhile(unicode.IsSpace)
+}
+
+// skipWhile moves past the runes that match, a byte that isn't valid UTF-8
+// ends the run like any rune that doesn't match
+func (p *filterParser) skipWhile(match func(rune) bool) {
+	for r, size := p.peek(); size > 0 && match(r); r, size = p.peek() {
+		if r == utf8.RuneError && size == 1 {
+			return
+		}
+		p.pos += size
+	}
+}
+
+// peek decodes the rune at the position, its size is 0 at the end
+func (p *filterParser) peek() (rune, int) {
+	return utf8.DecodeRuneInString(p.rest())
+}
+
+func (p *filterParser) rest() string {
+	return p.input[p.pos:]
+}
@@ -0,0 +1,144 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+	"strings"
+	"time"
+)
+
+func (s *TaskUseCaseTestSuite) TestListTaskFilter() {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	scoped := func(query bson.M) bson.M {
+		return bson.M{"owner_id": "user-1", "$and": []bson.M{query}}
+	}
+
+	tests := []struct {
+		name       string
+		filter     string
+		want       bson.M
+		wantErrMsg string
+	}{
+		{
+			name:   "multi-value status and a date",
+			filter: "status:in(backlog,in_progress) AND created_at>2026-01-01",
+			want: scoped(bson.M{"$and": []bson.M{
+				{"status": bson.M{"$in": []any{model.TaskStatusBacklog, model.TaskStatusInProgress}}},
+				{"created_at": bson.M{"$gt": jan}},
+			}}),
+		},
+		{
+			name:   "date range with a time",
+			filter: "updated_at>=2026-01-01 and updated_at<2026-01-01T07:00:00+07:00",
+			want: scoped(bson.M{"$and": []bson.M{
+				{"updated_at": bson.M{"$gte": jan}},
+				{"updated_at": bson.M{"$lt": jan}},
+			}}),
+		},
+		{
+			name:   "AND binds tighter than OR",
+			filter: "priority:urgent OR priority:high AND tags:Backend",
+			want: scoped(bson.M{"$or": []bson.M{
+				{"priority": model.TaskPriorityUrgent},
+				{"$and": []bson.M{{"priority": model.TaskPriorityHigh}, {"tags": "backend"}}},
+			}}),
+		},
+		{
+			name:   "negation of a group and of a value",
+			filter: `NOT (tags:"wontfix" OR due_date<=2026-01-01) AND status!=in(completed,cancelled)`,
+			want: scoped(bson.M{"$and": []bson.M{
+				{"$nor": []bson.M{{"$or": []bson.M{{"tags": "wontfix"}, {"due_date": bson.M{"$lte": jan}}}}}},
+				{"status": bson.M{"$nin": []any{model.TaskStatusCompleted, model.TaskStatusCancelled}}},
+			}}),
+		},
+		{
+			name:       "unknown field",
+			filter:     "owner_id:user-2",
+			wantErrMsg: `filter: unknown field "owner_id" at position 1`,
+		},
+		{
+			name:       "unsupported operator",
+			filter:     "status:todo AND created_at:2026-01-01",
+			wantErrMsg: `filter: created_at doesn't support the operator ":" at position 27`,
+		},
+		{
+			name:       "operator outside the grammar",
+			filter:     "status=todo",
+			wantErrMsg: `filter: status doesn't support the operator "=" at position 7`,
+		},
+		{
+			name:       "comparison on a status",
+			filter:     "status>todo",
+			wantErrMsg: `filter: status doesn't support the operator ">" at position 7`,
+		},
+		{
+			name:       "unknown status",
+			filter:     "status:in(todo,done)",
+			wantErrMsg: `filter: status must be a task status, not "done" at position 16`,
+		},
+		{
+			name:       "not a date",
+			filter:     "created_at>yesterday",
+			wantErrMsg: `filter: created_at must be a date or an RFC 3339 time, not "yesterday" at position 12`,
+		},
+		{
+			name:       "unclosed group",
+			filter:     "(status:todo OR status:backlog",
+			wantErrMsg: "filter: missing ) at position 31",
+		},
+		{
+			name:       "trailing input",
+			filter:     "status:todo priority:low",
+			wantErrMsg: `filter: unexpected "priority:low" at position 13`,
+		},
+		{
+			name:       "too many conditions",
+			filter:     strings.Repeat("status:todo OR ", 20) + "status:todo",
+			wantErrMsg: "filter: more than 20 conditions at position 301",
+		},
+		{
+			name:       "groups too deep",
+			filter:     strings.Repeat("(", 6) + "status:todo" + strings.Repeat(")", 6),
+			wantErrMsg: "filter: groups and NOTs nest deeper than 5 at position 7",
+		},
+		{
+			name:       "NOTs too deep",
+			filter:     strings.Repeat("NOT ", 200) + "status:todo",
+			wantErrMsg: "filter: groups and NOTs nest deeper than 5 at position 24",
+		},
+		{
+			name:       "unknown field with a non-ASCII letter",
+			filter:     "statusé:todo",
+			wantErrMsg: `filter: unknown field "statusé" at position 1`,
+		},
+		{
+			name:       "non-ASCII quoted value",
+			filter:     `tags:"bügfix\é"`,
+			wantErrMsg: `filter: tags must be a tag, not "bügfixé" at position 6`,
+		},
+		{
+			name:       "NOTs and groups count together",
+			filter:     "NOT (NOT (NOT (status:todo)))",
+			wantErrMsg: "filter: groups and NOTs nest deeper than 5 at position 16",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			params := model.TaskListParam{Limit: 10, Page: 1, Filter: tt.filter}
+			if tt.wantErrMsg != "" {
+				_, _, err := s.UseCase.ListTask(context.TODO(), member, params)
+				s.ErrorIs(err, usecase.ErrInvalidFilter)
+				s.EqualError(err, tt.wantErrMsg)
+				return
+			}
+
+			s.TaskMongoRepository.EXPECT().List(mock.Anything, tt.want, int64(1), int64(10), []model.SortKey(nil), "").
+				Return(nil, 0, nil).Once()
+			_, _, err := s.UseCase.ListTask(context.TODO(), member, params)
+			s.NoError(err)
+		})
+	}
+}
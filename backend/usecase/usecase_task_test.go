@@ -8,16 +8,24 @@ import (
 	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"testing"
 	"time"
 )
 
+var (
+	member = model.Principal{UserID: "user-1", Role: model.RoleMember}
+	admin  = model.Principal{UserID: "admin-1", Role: model.RoleAdmin}
+	viewer = model.Principal{UserID: "viewer-1", Role: model.RoleViewer}
+)
+
 type TaskUseCaseTestSuite struct {
 	suite.Suite
 
 	TaskMongoRepository *mocks.TaskMongoRepository
+	TaskEventRepository *mocks.TaskEventRepository
 	UseCase             usecase.TaskUseCase
 }
 
@@ -29,11 +37,21 @@ func (s *TaskUseCaseTestSuite) SetupTest() {
 	t := s.T()
 
 	s.TaskMongoRepository = mocks.NewTaskMongoRepository(t)
+	s.TaskEventRepository = mocks.NewTaskEventRepository(t)
 	s.UseCase = usecase.NewTaskUseCase(
 		s.TaskMongoRepository,
+		s.TaskEventRepository,
+		model.DefaultTaskWorkflow(),
 	)
 }
 
+// expectEvent expects one audit event for an action of member
+func (s *TaskUseCaseTestSuite) expectEvent(action model.TaskEventAction, changes int) {
+	s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e *model.TaskEvent) bool {
+		return e.Action == action && e.ActorID == "user-1" && len(e.Changes) == changes
+	})).Return(nil).Once()
+}
+
 func (s *TaskUseCaseTestSuite) TestListTask() {
 	type args struct {
 		ctx    context.Context
@@ -59,7 +77,7 @@ func (s *TaskUseCaseTestSuite) TestListTask() {
 				},
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().List(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, mock.MatchedBy(func(f bson.M) bool { return f["owner_id"] == "user-1" }), mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, 0, errors.New("some error")).Once()
 			},
 			afterTest: func() {
@@ -79,7 +97,7 @@ func (s *TaskUseCaseTestSuite) TestListTask() {
 				},
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().List(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, mock.MatchedBy(func(f bson.M) bool { return f["owner_id"] == "user-1" }), mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return([]*model.Task{{
 						ID:          primitive.NewObjectID(),
 						Title:       "TASK",
@@ -99,7 +117,7 @@ func (s *TaskUseCaseTestSuite) TestListTask() {
 		s.Run(tt.name, func() {
 
 			tt.mock()
-			_, size, err := s.UseCase.ListTask(tt.args.ctx, tt.args.params)
+			_, size, err := s.UseCase.ListTask(tt.args.ctx, member, tt.args.params)
 			tt.afterTest()
 			if tt.wantErr {
 				s.Error(err)
@@ -110,12 +128,23 @@ func (s *TaskUseCaseTestSuite) TestListTask() {
 			}
 		})
 	}
+
+	s.Run("viewer lists every owner", func() {
+		s.TaskMongoRepository.EXPECT().List(mock.Anything, mock.MatchedBy(func(f bson.M) bool { _, scoped := f["owner_id"]; return !scoped }), mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*model.Task{{ID: primitive.NewObjectID(), OwnerID: "user-1", Title: "TASK", Status: "backlog"}}, 1, nil).Once()
+
+		res, size, err := s.UseCase.ListTask(context.TODO(), viewer, model.TaskListParam{Limit: 10, Page: 1})
+		s.NoError(err)
+		s.Equal(1, size)
+		s.Len(res, 1)
+	})
 }
 
 func (s *TaskUseCaseTestSuite) TestGetTask() {
 	type args struct {
-		ctx context.Context
-		id  string
+		ctx       context.Context
+		id        string
+		principal model.Principal
 	}
 	tests := []struct {
 		name       string
@@ -132,14 +161,14 @@ func (s *TaskUseCaseTestSuite) TestGetTask() {
 				id:  "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything, "user-1").
 					Return(nil, mongo.ErrNoDocuments).Once()
 			},
 			afterTest: func() {
 
 			},
 			wantErr:    true,
-			wantErrMsg: mongo.ErrNoDocuments.Error(),
+			wantErrMsg: "data not found",
 		},
 		{
 			name: "error - get some error",
@@ -148,7 +177,7 @@ func (s *TaskUseCaseTestSuite) TestGetTask() {
 				id:  "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything, "user-1").
 					Return(nil, errors.New("some error")).Once()
 			},
 			afterTest: func() {
@@ -164,7 +193,7 @@ func (s *TaskUseCaseTestSuite) TestGetTask() {
 				id:  "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything, "user-1").
 					Return(&model.Task{
 						ID:          primitive.NewObjectID(),
 						Title:       "TASK",
@@ -175,6 +204,50 @@ func (s *TaskUseCaseTestSuite) TestGetTask() {
 			},
 			afterTest: func() {
 
+			},
+			wantErr:    false,
+			wantErrMsg: "",
+		},
+		{
+			name: "success - viewer reads any owner",
+			args: args{
+				ctx:       context.TODO(),
+				id:        "68fc6a818c54acf4a737d7ab",
+				principal: viewer,
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything, "").
+					Return(&model.Task{
+						ID:      primitive.NewObjectID(),
+						OwnerID: "user-1",
+						Title:   "TASK",
+						Status:  "backlog",
+					}, nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    false,
+			wantErrMsg: "",
+		},
+		{
+			name: "success - admin reads any owner",
+			args: args{
+				ctx:       context.TODO(),
+				id:        "68fc6a818c54acf4a737d7ab",
+				principal: admin,
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, mock.Anything, "").
+					Return(&model.Task{
+						ID:      primitive.NewObjectID(),
+						OwnerID: "user-1",
+						Title:   "TASK",
+						Status:  "backlog",
+					}, nil).Once()
+			},
+			afterTest: func() {
+
 			},
 			wantErr:    false,
 			wantErrMsg: "",
@@ -184,7 +257,11 @@ func (s *TaskUseCaseTestSuite) TestGetTask() {
 		s.Run(tt.name, func() {
 
 			tt.mock()
-			_, err := s.UseCase.GetTask(tt.args.ctx, tt.args.id)
+			principal := member
+			if tt.args.principal.UserID != "" {
+				principal = tt.args.principal
+			}
+			_, err := s.UseCase.GetTask(tt.args.ctx, principal, tt.args.id)
 			tt.afterTest()
 			if tt.wantErr {
 				s.Error(err)
@@ -220,7 +297,7 @@ func (s *TaskUseCaseTestSuite) TestCreateTask() {
 				},
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().Create(mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(t *model.Task) bool { return t.OwnerID == "user-1" })).
 					Return(nil, errors.New("some error")).Once()
 			},
 			afterTest: func() {
@@ -240,7 +317,7 @@ func (s *TaskUseCaseTestSuite) TestCreateTask() {
 				},
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().Create(mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(t *model.Task) bool { return t.OwnerID == "user-1" })).
 					Return(&model.Task{
 						ID:          primitive.NewObjectID(),
 						Title:       "TASK",
@@ -248,6 +325,7 @@ func (s *TaskUseCaseTestSuite) TestCreateTask() {
 						Status:      "backlog",
 						CreatedAt:   time.Now(),
 					}, nil).Once()
+				s.expectEvent(model.TaskEventCreated, 3)
 			},
 			afterTest: func() {
 
@@ -259,7 +337,7 @@ func (s *TaskUseCaseTestSuite) TestCreateTask() {
 		s.Run(tt.name, func() {
 
 			tt.mock()
-			_, err := s.UseCase.CreateTask(tt.args.ctx, tt.args.params)
+			_, err := s.UseCase.CreateTask(tt.args.ctx, member, tt.args.params)
 			tt.afterTest()
 			if tt.wantErr {
 				s.Error(err)
@@ -273,10 +351,16 @@ func (s *TaskUseCaseTestSuite) TestCreateTask() {
 
 func (s *TaskUseCaseTestSuite) TestUpdateTask() {
 	type args struct {
-		ctx    context.Context
-		params model.TaskBodyParam
-		id     string
+		ctx     context.Context
+		params  model.TaskBodyParam
+		id      string
+		version int64
+	}
+	id, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7ab")
+	stored := func(status model.TaskStatus) *model.Task {
+		return &model.Task{ID: id, OwnerID: "user-1", Title: "TASK", Status: status, Version: 3}
 	}
+
 	tests := []struct {
 		name       string
 		args       args
@@ -285,22 +369,6 @@ func (s *TaskUseCaseTestSuite) TestUpdateTask() {
 		wantErr    bool
 		wantErrMsg string
 	}{
-		{
-			name: "error - no update data provide",
-			args: args{
-				ctx:    context.TODO(),
-				params: model.TaskBodyParam{},
-				id:     "68fc6a818c54acf4a737d7ab",
-			},
-			mock: func() {
-
-			},
-			afterTest: func() {
-
-			},
-			wantErr:    true,
-			wantErrMsg: "no update data provided",
-		},
 		{
 			name: "error - no document found",
 			args: args{
@@ -313,14 +381,14 @@ func (s *TaskUseCaseTestSuite) TestUpdateTask() {
 				id: "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
 					Return(nil, mongo.ErrNoDocuments).Once()
 			},
 			afterTest: func() {
 
 			},
 			wantErr:    true,
-			wantErrMsg: mongo.ErrNoDocuments.Error(),
+			wantErrMsg: "data not found",
 		},
 		{
 			name: "error - update",
@@ -334,7 +402,9 @@ func (s *TaskUseCaseTestSuite) TestUpdateTask() {
 				id: "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusBacklog), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, mock.Anything, "user-1", int64(3), mock.Anything).
 					Return(nil, errors.New("some error")).Once()
 			},
 			afterTest: func() {
@@ -355,7 +425,9 @@ func (s *TaskUseCaseTestSuite) TestUpdateTask() {
 				id: "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().Update(mock.Anything, mock.Anything, mock.Anything).
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, mock.Anything, "user-1", int64(3), mock.Anything).
 					Return(&model.Task{
 						ID:          primitive.NewObjectID(),
 						Title:       "TASK",
@@ -363,18 +435,142 @@ func (s *TaskUseCaseTestSuite) TestUpdateTask() {
 						Status:      "backlog",
 						CreatedAt:   time.Now(),
 					}, nil).Once()
+				s.expectEvent(model.TaskEventUpdated, 2)
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+		{
+			name: "error - completed task can't go back to backlog",
+			args: args{
+				ctx: context.TODO(),
+				params: model.TaskBodyParam{
+					Title:  "title",
+					Status: model.TaskStatusBacklog,
+				},
+				id: "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusCompleted), nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "a task can't move from completed to backlog",
+		},
+		{
+			name: "success - replace clears description",
+			args: args{
+				ctx: context.TODO(),
+				params: model.TaskBodyParam{
+					Title:  "title",
+					Status: model.TaskStatusInProgress,
+				},
+				id: "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusCompleted), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(3), bson.M{
+					"title":         "title",
+					"description":   "",
+					"status":        model.TaskStatusInProgress,
+					"priority":      model.TaskPriorityMedium,
+					"priority_rank": 2,
+					"due_date":      (*time.Time)(nil),
+					"tags":          []string(nil),
+					"completed_at":  nil,
+				}).Return(stored(model.TaskStatusInProgress), nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, &model.TaskEvent{
+					TaskID:  "68fc6a818c54acf4a737d7ab",
+					OwnerID: "user-1",
+					Action:  model.TaskEventUpdated,
+					ActorID: "user-1",
+					Changes: []model.FieldChange{{Field: "status", Before: "completed", After: "in_progress"}},
+				}).Return(nil).Once()
 			},
 			afterTest: func() {
 
 			},
 			wantErr: false,
 		},
+		{
+			name: "error - client has an old version",
+			args: args{
+				ctx: context.TODO(),
+				params: model.TaskBodyParam{
+					Title:  "title",
+					Status: model.TaskStatusTodo,
+				},
+				id:      "68fc6a818c54acf4a737d7ab",
+				version: 2,
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "task was changed since it was read",
+		},
+		{
+			name: "error - changed between the check and the write",
+			args: args{
+				ctx: context.TODO(),
+				params: model.TaskBodyParam{
+					Title:  "title",
+					Status: model.TaskStatusTodo,
+				},
+				id:      "68fc6a818c54acf4a737d7ab",
+				version: 3,
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Twice()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(3), mock.Anything).
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "task was changed since it was read",
+		},
+		{
+			name: "error - concurrent update without If-Match",
+			args: args{
+				ctx: context.TODO(),
+				params: model.TaskBodyParam{
+					Title:  "title",
+					Status: model.TaskStatusTodo,
+				},
+				id: "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Twice()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(3), mock.Anything).
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "task was changed by another request, try again",
+		},
 	}
+
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 
 			tt.mock()
-			_, err := s.UseCase.UpdateTask(tt.args.ctx, tt.args.id, tt.args.params)
+			_, err := s.UseCase.UpdateTask(tt.args.ctx, member, tt.args.id, tt.args.version, tt.args.params)
 			tt.afterTest()
 			if tt.wantErr {
 				s.Error(err)
@@ -386,11 +582,26 @@ func (s *TaskUseCaseTestSuite) TestUpdateTask() {
 	}
 }
 
-func (s *TaskUseCaseTestSuite) TestDeleteTask() {
+func (s *TaskUseCaseTestSuite) TestPatchTask() {
 	type args struct {
-		ctx context.Context
-		id  string
+		ctx     context.Context
+		params  model.TaskPatchParam
+		id      string
+		version int64
+	}
+	id, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7ab")
+	stored := func(status model.TaskStatus) *model.Task {
+		return &model.Task{ID: id, OwnerID: "user-1", Title: "TASK", Status: status, Version: 3}
 	}
+
+	title := "title"
+	renamed := func() *model.Task {
+		task := stored(model.TaskStatusTodo)
+		task.Title = title
+		return task
+	}
+	status := model.TaskStatusBacklog
+
 	tests := []struct {
 		name       string
 		args       args
@@ -400,43 +611,155 @@ func (s *TaskUseCaseTestSuite) TestDeleteTask() {
 		wantErrMsg string
 	}{
 		{
-			name: "error - get some error",
+			name: "error - empty patch",
 			args: args{
-				ctx: context.TODO(),
-				id:  "68fc6a818c54acf4a737d7ab",
+				ctx:    context.TODO(),
+				params: model.TaskPatchParam{},
+				id:     "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().Delete(mock.Anything, mock.Anything).
-					Return(errors.New("some error")).Once()
+
 			},
 			afterTest: func() {
 
 			},
 			wantErr:    true,
-			wantErrMsg: "some error",
+			wantErrMsg: "no update data provided",
 		},
 		{
-			name: "success",
+			name: "error - title can't be cleared",
 			args: args{
-				ctx: context.TODO(),
-				id:  "68fc6a818c54acf4a737d7ab",
+				ctx:    context.TODO(),
+				params: model.TaskPatchParam{Null: []string{"title"}},
+				id:     "68fc6a818c54acf4a737d7ab",
 			},
 			mock: func() {
-				s.TaskMongoRepository.EXPECT().Delete(mock.Anything, mock.Anything).
-					Return(nil).Once()
+
 			},
 			afterTest: func() {
 
 			},
-			wantErr:    false,
-			wantErrMsg: "",
+			wantErr:    true,
+			wantErrMsg: "title can't be cleared",
+		},
+		{
+			name: "error - no document found",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.TaskPatchParam{Title: &title},
+				id:     "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "data not found",
+		},
+		{
+			name: "error - completed task can't go back to backlog",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.TaskPatchParam{Status: &status},
+				id:     "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusCompleted), nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "a task can't move from completed to backlog",
+		},
+		{
+			name: "success - title only keeps the other fields",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.TaskPatchParam{Title: &title},
+				id:     "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(3), bson.M{"title": "title"}).
+					Return(renamed(), nil).Once()
+				s.expectEvent(model.TaskEventUpdated, 1)
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - null clears description",
+			args: args{
+				ctx:    context.TODO(),
+				params: model.TaskPatchParam{Status: &status, Null: []string{"description"}},
+				id:     "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(3), bson.M{
+					"description": "",
+					"status":      model.TaskStatusBacklog,
+				}).Return(stored(model.TaskStatusBacklog), nil).Once()
+				s.expectEvent(model.TaskEventUpdated, 1)
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+		{
+			name: "error - version mismatch",
+			args: args{
+				ctx:     context.TODO(),
+				params:  model.TaskPatchParam{Title: &title},
+				id:      "68fc6a818c54acf4a737d7ab",
+				version: 2,
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "task was changed since it was read",
+		},
+		{
+			name: "success - matching version",
+			args: args{
+				ctx:     context.TODO(),
+				params:  model.TaskPatchParam{Title: &title},
+				id:      "68fc6a818c54acf4a737d7ab",
+				version: 3,
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(stored(model.TaskStatusTodo), nil).Once()
+				s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(3), bson.M{"title": "title"}).
+					Return(stored(model.TaskStatusTodo), nil).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
 		},
 	}
+
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 
 			tt.mock()
-			err := s.UseCase.DeleteTask(tt.args.ctx, tt.args.id)
+			_, err := s.UseCase.PatchTask(tt.args.ctx, member, tt.args.id, tt.args.version, tt.args.params)
 			tt.afterTest()
 			if tt.wantErr {
 				s.Error(err)
@@ -447,3 +770,768 @@ func (s *TaskUseCaseTestSuite) TestDeleteTask() {
 		})
 	}
 }
+
+func (s *TaskUseCaseTestSuite) TestDeleteTask() {
+	type args struct {
+		ctx     context.Context
+		id      string
+		version int64
+	}
+	id, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7ab")
+
+	tests := []struct {
+		name       string
+		args       args
+		mock       func()
+		afterTest  func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "error - not found",
+			args: args{
+				ctx: context.TODO(),
+				id:  "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "data not found",
+		},
+		{
+			name: "error - malformed id",
+			args: args{
+				ctx: context.TODO(),
+				id:  "xxx",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "xxx", "user-1").
+					Return(nil, primitive.ErrInvalidHex).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "data not found",
+		},
+		{
+			name: "error - get some error",
+			args: args{
+				ctx: context.TODO(),
+				id:  "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(&model.Task{ID: id, OwnerID: "user-1", Version: 3}, nil).Once()
+				s.TaskMongoRepository.EXPECT().Delete(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(0), "user-1").
+					Return(errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.TODO(),
+				id:  "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(&model.Task{ID: id, OwnerID: "user-1", Version: 3}, nil).Once()
+				s.TaskMongoRepository.EXPECT().Delete(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(0), "user-1").
+					Return(nil).Once()
+				s.expectEvent(model.TaskEventDeleted, 0)
+			},
+			afterTest: func() {
+
+			},
+			wantErr:    false,
+			wantErrMsg: "",
+		},
+		{
+			name: "success - a failing audit event doesn't fail the delete",
+			args: args{
+				ctx: context.TODO(),
+				id:  "68fc6a818c54acf4a737d7ab",
+			},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(&model.Task{ID: id, OwnerID: "user-1", Version: 3}, nil).Once()
+				s.TaskMongoRepository.EXPECT().Delete(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(0), "user-1").
+					Return(nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).
+					Return(errors.New("some error")).Once()
+			},
+			afterTest: func() {
+
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			tt.mock()
+			err := s.UseCase.DeleteTask(tt.args.ctx, member, tt.args.id, tt.args.version)
+			tt.afterTest()
+			if tt.wantErr {
+				s.Error(err)
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestListTrash() {
+	deletedAt := time.Now()
+	trash := bson.M{"deleted_at": bson.M{"$ne": nil}, "owner_id": "user-1"}
+
+	tests := []struct {
+		name       string
+		principal  model.Principal
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+		size       int
+	}{
+		{
+			name:      "error - get list",
+			principal: member,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, trash, int64(1), int64(10), []model.SortKey{{Field: "deleted_at", Order: -1}}, "").
+					Return(nil, 0, errors.New("some error")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name:      "success - member sees own trash",
+			principal: member,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, trash, int64(1), int64(10), []model.SortKey{{Field: "deleted_at", Order: -1}}, "").
+					Return([]*model.Task{{
+						ID:        primitive.NewObjectID(),
+						Title:     "TASK",
+						Status:    "backlog",
+						DeletedAt: &deletedAt,
+						DeletedBy: "user-1",
+					}}, 1, nil).Once()
+			},
+			size: 1,
+		},
+		{
+			name:      "success - admin sees every trash",
+			principal: admin,
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, bson.M{"deleted_at": bson.M{"$ne": nil}}, int64(1), int64(10), []model.SortKey{{Field: "deleted_at", Order: -1}}, "").
+					Return(nil, 0, nil).Once()
+			},
+			size: 0,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+			res, size, err := s.UseCase.ListTrash(context.TODO(), tt.principal, model.TaskTrashParam{Limit: 10, Page: 1})
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.size, size)
+				for _, task := range res {
+					s.NotNil(task.DeletedAt)
+				}
+			}
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestRestoreTask() {
+	tests := []struct {
+		name       string
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "error - not in the trash",
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().Restore(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "data not found",
+		},
+		{
+			name: "success",
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().Restore(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(&model.Task{ID: primitive.NewObjectID(), Title: "TASK", Status: "backlog", Version: 4}, nil).Once()
+				s.expectEvent(model.TaskEventRestored, 0)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+			res, err := s.UseCase.RestoreTask(context.TODO(), member, "68fc6a818c54acf4a737d7ab")
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+				s.Equal(int64(4), res.Version)
+				s.Nil(res.DeletedAt)
+			}
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestPurgeTrash() {
+	retained := mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour && time.Since(before) < 25*time.Hour
+	})
+
+	s.Run("nothing to purge", func() {
+		s.TaskMongoRepository.EXPECT().Purge(mock.Anything, retained).Return(nil, nil).Once()
+
+		purged, err := s.UseCase.PurgeTrash(context.TODO(), 24*time.Hour)
+		s.NoError(err)
+		s.Zero(purged)
+	})
+
+	s.Run("purged tasks lose their history and dependencies", func() {
+		ids := []string{"68fc6a818c54acf4a737d7ab", "68fc6a818c54acf4a737d7ac"}
+		s.TaskMongoRepository.EXPECT().Purge(mock.Anything, retained).Return(ids, nil).Once()
+		s.TaskMongoRepository.EXPECT().RemoveBlockers(mock.Anything, ids).Return(nil).Once()
+		s.TaskEventRepository.EXPECT().DeleteByTasks(mock.Anything, ids).Return(nil).Once()
+
+		purged, err := s.UseCase.PurgeTrash(context.TODO(), 24*time.Hour)
+		s.NoError(err)
+		s.Equal(int64(2), purged)
+	})
+
+	s.Run("error - the tasks purged before a failure are cleaned up", func() {
+		ids := []string{"68fc6a818c54acf4a737d7ab"}
+		s.TaskMongoRepository.EXPECT().Purge(mock.Anything, retained).Return(ids, errors.New("some error")).Once()
+		s.TaskMongoRepository.EXPECT().RemoveBlockers(mock.Anything, ids).Return(nil).Once()
+		s.TaskEventRepository.EXPECT().DeleteByTasks(mock.Anything, ids).Return(nil).Once()
+
+		purged, err := s.UseCase.PurgeTrash(context.TODO(), 24*time.Hour)
+		s.EqualError(err, "some error")
+		s.Equal(int64(1), purged)
+	})
+
+	s.Run("error - remove blockers", func() {
+		ids := []string{"68fc6a818c54acf4a737d7ab"}
+		s.TaskMongoRepository.EXPECT().Purge(mock.Anything, retained).Return(ids, nil).Once()
+		s.TaskMongoRepository.EXPECT().RemoveBlockers(mock.Anything, ids).Return(errors.New("some error")).Once()
+
+		_, err := s.UseCase.PurgeTrash(context.TODO(), 24*time.Hour)
+		s.EqualError(err, "some error")
+	})
+}
+
+func (s *TaskUseCaseTestSuite) TestListTags() {
+	s.Run("member sees their tags", func() {
+		s.TaskMongoRepository.EXPECT().Tags(mock.Anything, "user-1").
+			Return([]model.TagCount{{Name: "backend", Count: 2}}, nil).Once()
+
+		res, err := s.UseCase.ListTags(context.TODO(), member)
+		s.NoError(err)
+		s.Equal([]model.TagCount{{Name: "backend", Count: 2}}, res)
+	})
+
+	s.Run("admin sees every tag", func() {
+		s.TaskMongoRepository.EXPECT().Tags(mock.Anything, "").Return([]model.TagCount{}, nil).Once()
+
+		_, err := s.UseCase.ListTags(context.TODO(), admin)
+		s.NoError(err)
+	})
+
+	s.Run("viewer sees every tag", func() {
+		s.TaskMongoRepository.EXPECT().Tags(mock.Anything, "").Return([]model.TagCount{}, nil).Once()
+
+		_, err := s.UseCase.ListTags(context.TODO(), viewer)
+		s.NoError(err)
+	})
+}
+
+func (s *TaskUseCaseTestSuite) TestRenameTag() {
+	first, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7ab")
+	second, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7ac")
+	tagged := func() []*model.Task {
+		return []*model.Task{
+			{ID: first, OwnerID: "user-1", Tags: []string{"bug", "ui"}, Version: 2},
+			{ID: second, OwnerID: "user-1", Tags: []string{"defect", "bug"}, Version: 5},
+		}
+	}
+	renamed := func(id primitive.ObjectID, before, after []string) *model.TaskEvent {
+		return &model.TaskEvent{
+			TaskID:  id.Hex(),
+			OwnerID: "user-1",
+			Action:  model.TaskEventUpdated,
+			ActorID: "user-1",
+			Changes: []model.FieldChange{{Field: "tags", Before: before, After: after}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		from, to   string
+		mock       func()
+		want       *model.TagRenameResponse
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "error - rename",
+			from: "bug",
+			to:   "defect",
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().RenameTag(mock.Anything, "user-1", "bug", "defect").
+					Return(nil, errors.New("some error")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "error - rename fails part way, the renamed task is recorded",
+			from: "bug",
+			to:   "defect",
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().RenameTag(mock.Anything, "user-1", "bug", "defect").
+					Return(tagged()[:1], errors.New("some error")).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, renamed(first, []string{"bug", "ui"}, []string{"defect", "ui"})).
+					Return(nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "error - unknown tag",
+			from: "bug",
+			to:   "defect",
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().RenameTag(mock.Anything, "user-1", "bug", "defect").
+					Return(nil, nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "no task has the tag bug",
+		},
+		{
+			name: "success - same name",
+			from: "bug",
+			to:   " Bug",
+			mock: func() {},
+			want: &model.TagRenameResponse{From: "bug", To: "bug"},
+		},
+		{
+			name: "success - every renamed task gets an updated event",
+			from: "Bug",
+			to:   "defect",
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().RenameTag(mock.Anything, "user-1", "bug", "defect").
+					Return(tagged(), nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, renamed(first, []string{"bug", "ui"}, []string{"defect", "ui"})).
+					Return(nil).Once()
+				s.TaskEventRepository.EXPECT().Create(mock.Anything, renamed(second, []string{"defect", "bug"}, []string{"defect"})).
+					Return(nil).Once()
+			},
+			want: &model.TagRenameResponse{From: "bug", To: "defect", Updated: 2},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+			res, err := s.UseCase.RenameTag(context.TODO(), member, tt.from, tt.to)
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+				return
+			}
+			s.NoError(err)
+			s.Equal(tt.want, res)
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestTaskHistory() {
+	param := model.TaskHistoryParam{Limit: 10, Page: 1}
+
+	tests := []struct {
+		name       string
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+		size       int
+	}{
+		{
+			name: "error - list events",
+			mock: func() {
+				s.TaskEventRepository.EXPECT().ListByTask(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(1), int64(10)).
+					Return(nil, 0, errors.New("some error")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "some error",
+		},
+		{
+			name: "error - task of someone else",
+			mock: func() {
+				s.TaskEventRepository.EXPECT().ListByTask(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(1), int64(10)).
+					Return(nil, 0, nil).Once()
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(nil, mongo.ErrNoDocuments).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "data not found",
+		},
+		{
+			name: "success - task older than the audit trail",
+			mock: func() {
+				s.TaskEventRepository.EXPECT().ListByTask(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(1), int64(10)).
+					Return(nil, 0, nil).Once()
+				s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+					Return(&model.Task{}, nil).Once()
+			},
+			size: 0,
+		},
+		{
+			name: "success",
+			mock: func() {
+				s.TaskEventRepository.EXPECT().ListByTask(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(1), int64(10)).
+					Return([]*model.TaskEvent{
+						{TaskID: "68fc6a818c54acf4a737d7ab", Action: model.TaskEventUpdated, ActorID: "user-1", Changes: []model.FieldChange{
+							{Field: "status", Before: "todo", After: "in_progress"},
+						}},
+						{TaskID: "68fc6a818c54acf4a737d7ab", Action: model.TaskEventCreated, ActorID: "user-1"},
+					}, 2, nil).Once()
+			},
+			size: 2,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+			res, size, err := s.UseCase.TaskHistory(context.TODO(), member, "68fc6a818c54acf4a737d7ab", param)
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+			} else {
+				s.NoError(err)
+				s.Equal(tt.size, size)
+				s.Len(res, tt.size)
+			}
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestListTaskFilters() {
+	dueAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	dueBefore := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	farFuture := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		params model.TaskListParam
+		check  func(filter bson.M) bool
+	}{
+		{
+			name:   "priority and due range",
+			params: model.TaskListParam{Limit: 10, Page: 1, Priority: model.TaskPriorityHigh, DueAfter: dueAfter, DueBefore: dueBefore},
+			check: func(filter bson.M) bool {
+				return filter["priority"] == model.TaskPriorityHigh &&
+					s.Equal(bson.M{"$gte": dueAfter, "$lt": dueBefore}, filter["due_date"]) &&
+					filter["status"] == nil
+			},
+		},
+		{
+			name:   "overdue leaves out closed tasks",
+			params: model.TaskListParam{Limit: 10, Page: 1, Status: model.TaskStatusTodo, Overdue: true, DueBefore: farFuture},
+			check: func(filter bson.M) bool {
+				due := filter["due_date"].(bson.M)
+				return due["$lt"].(time.Time).Before(farFuture) &&
+					s.Equal(bson.M{"$eq": model.TaskStatusTodo, "$nin": model.ClosedTaskStatuses}, filter["status"])
+			},
+		},
+		{
+			name:   "overdue keeps an earlier due_before",
+			params: model.TaskListParam{Limit: 10, Page: 1, Overdue: true, DueBefore: dueBefore},
+			check: func(filter bson.M) bool {
+				return s.Equal(bson.M{"$lt": dueBefore}, filter["due_date"])
+			},
+		},
+		{
+			name:   "any of the tags",
+			params: model.TaskListParam{Limit: 10, Page: 1, Tags: "Backend, bug,,backend"},
+			check: func(filter bson.M) bool {
+				return s.Equal(bson.M{"$in": []string{"backend", "bug"}}, filter["tags"])
+			},
+		},
+		{
+			name:   "all of the tags",
+			params: model.TaskListParam{Limit: 10, Page: 1, Tags: "backend,bug", TagMatch: model.TagMatchAll},
+			check: func(filter bson.M) bool {
+				return s.Equal(bson.M{"$all": []string{"backend", "bug"}}, filter["tags"])
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.TaskMongoRepository.EXPECT().List(mock.Anything, mock.Anything, int64(1), int64(10), []model.SortKey(nil), "").
+				Run(func(_ context.Context, filter bson.M, _, _ int64, _ []model.SortKey, _ string) {
+					s.True(tt.check(filter), "filter %v", filter)
+				}).
+				Return(nil, 0, nil).Once()
+
+			_, _, err := s.UseCase.ListTask(context.TODO(), member, tt.params)
+			s.NoError(err)
+		})
+	}
+}
+
+func (s *TaskUseCaseTestSuite) TestCompletedAt() {
+	id, _ := primitive.ObjectIDFromHex("68fc6a818c54acf4a737d7ab")
+	completed := model.TaskStatusCompleted
+
+	s.Run("create completed task", func() {
+		s.TaskMongoRepository.EXPECT().Create(mock.Anything, mock.MatchedBy(func(t *model.Task) bool {
+			return t.CompletedAt != nil && t.Priority == model.TaskPriorityMedium
+		})).Return(&model.Task{ID: id, OwnerID: "user-1", Status: completed}, nil).Once()
+		s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := s.UseCase.CreateTask(context.TODO(), member, model.TaskBodyParam{Title: "title", Status: completed})
+		s.NoError(err)
+	})
+
+	s.Run("patch to completed stamps completed_at", func() {
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+			Return(&model.Task{ID: id, OwnerID: "user-1", Status: model.TaskStatusInProgress, Version: 1}, nil).Once()
+		s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(1), mock.MatchedBy(func(set bson.M) bool {
+			_, ok := set["completed_at"].(time.Time)
+			return ok && set["status"] == completed
+		})).Return(&model.Task{ID: id, OwnerID: "user-1", Status: completed, Version: 2}, nil).Once()
+		s.TaskEventRepository.EXPECT().Create(mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := s.UseCase.PatchTask(context.TODO(), member, "68fc6a818c54acf4a737d7ab", 0, model.TaskPatchParam{Status: &completed})
+		s.NoError(err)
+	})
+
+	s.Run("priority can't be cleared", func() {
+		_, err := s.UseCase.PatchTask(context.TODO(), member, "68fc6a818c54acf4a737d7ab", 0, model.TaskPatchParam{Null: []string{"priority"}})
+		s.EqualError(err, "priority can't be cleared")
+	})
+
+	s.Run("patch tags records the diff", func() {
+		tags := []string{"Backend", "bug", "backend"}
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+			Return(&model.Task{ID: id, OwnerID: "user-1", Status: model.TaskStatusTodo, Tags: []string{"bug"}, Version: 1}, nil).Once()
+		s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(1), bson.M{"tags": []string{"backend", "bug"}}).
+			Return(&model.Task{ID: id, OwnerID: "user-1", Status: model.TaskStatusTodo, Tags: []string{"backend", "bug"}, Version: 2}, nil).Once()
+		s.TaskEventRepository.EXPECT().Create(mock.Anything, &model.TaskEvent{
+			TaskID:  "68fc6a818c54acf4a737d7ab",
+			OwnerID: "user-1",
+			Action:  model.TaskEventUpdated,
+			ActorID: "user-1",
+			Changes: []model.FieldChange{{Field: "tags", Before: []string{"bug"}, After: []string{"backend", "bug"}}},
+		}).Return(nil).Once()
+
+		_, err := s.UseCase.PatchTask(context.TODO(), member, "68fc6a818c54acf4a737d7ab", 0, model.TaskPatchParam{Tags: &tags})
+		s.NoError(err)
+	})
+
+	s.Run("null due_date clears it", func() {
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1").
+			Return(&model.Task{ID: id, OwnerID: "user-1", Status: model.TaskStatusTodo, Version: 1}, nil).Once()
+		s.TaskMongoRepository.EXPECT().Update(mock.Anything, "68fc6a818c54acf4a737d7ab", "user-1", int64(1), bson.M{"due_date": (*time.Time)(nil)}).
+			Return(&model.Task{ID: id, OwnerID: "user-1", Status: model.TaskStatusTodo, Version: 2}, nil).Once()
+
+		_, err := s.UseCase.PatchTask(context.TODO(), member, "68fc6a818c54acf4a737d7ab", 0, model.TaskPatchParam{Null: []string{"due_date"}})
+		s.NoError(err)
+	})
+}
+
+func (s *TaskUseCaseTestSuite) TestListTaskCursor() {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tasks := make([]*model.Task, 3)
+	for i := range tasks {
+		tasks[i] = &model.Task{ID: primitive.NewObjectID(), OwnerID: "user-1", Title: "task", CreatedAt: created.Add(time.Duration(i) * time.Hour)}
+	}
+	param := model.TaskListParam{Limit: 2, SortBy: "created_at", Order: -1}
+
+	var next string
+	s.Run("first page", func() {
+		s.TaskMongoRepository.EXPECT().ListAfter(mock.Anything, bson.M{"owner_id": "user-1"}, int64(3), []model.SortKey{{Field: "created_at", Order: -1}}, "", (*model.TaskCursor)(nil)).
+			Return(tasks, nil).Once()
+
+		res, cursor, err := s.UseCase.ListTaskCursor(context.TODO(), member, param)
+		s.NoError(err)
+		s.Len(res, 2)
+		s.NotEmpty(cursor)
+		next = cursor
+	})
+
+	s.Run("next page continues after the last task", func() {
+		s.TaskMongoRepository.EXPECT().ListAfter(mock.Anything, bson.M{"owner_id": "user-1"}, int64(3), []model.SortKey{{Field: "created_at", Order: -1}}, "", &model.TaskCursor{
+			Sort: "-created_at", Values: []any{tasks[1].CreatedAt}, ID: tasks[1].ID,
+		}).Return(tasks[2:], nil).Once()
+
+		param := param
+		param.Cursor = next
+		res, cursor, err := s.UseCase.ListTaskCursor(context.TODO(), member, param)
+		s.NoError(err)
+		s.Len(res, 1)
+		s.Empty(cursor)
+	})
+
+	s.Run("cursor of another sort", func() {
+		param := param
+		param.Cursor = next
+		param.SortBy = "title"
+		_, _, err := s.UseCase.ListTaskCursor(context.TODO(), member, param)
+		s.ErrorIs(err, usecase.ErrInvalidCursor)
+	})
+
+	s.Run("malformed cursor", func() {
+		param := param
+		param.Cursor = "not a cursor"
+		_, _, err := s.UseCase.ListTaskCursor(context.TODO(), member, param)
+		s.ErrorIs(err, usecase.ErrInvalidCursor)
+	})
+}
+
+func (s *TaskUseCaseTestSuite) TestListTaskSort() {
+	sort := []model.SortKey{{Field: "priority", Order: -1}, {Field: "due_date", Order: 1}}
+
+	s.Run("page sorts by every key", func() {
+		s.TaskMongoRepository.EXPECT().List(mock.Anything, bson.M{"owner_id": "user-1"}, int64(1), int64(10), sort, "").
+			Return(nil, 0, nil).Once()
+
+		_, _, err := s.UseCase.ListTask(context.TODO(), member, model.TaskListParam{Limit: 10, Page: 1, Sort: "-priority,due_date"})
+		s.NoError(err)
+	})
+
+	s.Run("cursor holds a value per key, nil when unset", func() {
+		tasks := []*model.Task{
+			{ID: primitive.NewObjectID(), OwnerID: "user-1", Priority: model.TaskPriorityUrgent, PriorityRank: 4},
+			{ID: primitive.NewObjectID(), OwnerID: "user-1", Priority: model.TaskPriorityHigh, PriorityRank: 3},
+		}
+		param := model.TaskListParam{Limit: 1, Sort: "-priority,due_date"}
+		s.TaskMongoRepository.EXPECT().ListAfter(mock.Anything, bson.M{"owner_id": "user-1"}, int64(2), sort, "", (*model.TaskCursor)(nil)).
+			Return(tasks, nil).Once()
+
+		_, next, err := s.UseCase.ListTaskCursor(context.TODO(), member, param)
+		s.NoError(err)
+
+		s.TaskMongoRepository.EXPECT().ListAfter(mock.Anything, bson.M{"owner_id": "user-1"}, int64(2), sort, "", &model.TaskCursor{
+			Sort: "-priority,due_date", Values: []any{4, nil}, ID: tasks[0].ID,
+		}).Return(tasks[1:], nil).Once()
+
+		param.Cursor = next
+		_, _, err = s.UseCase.ListTaskCursor(context.TODO(), member, param)
+		s.NoError(err)
+
+		param.Sort = "-priority,-due_date"
+		_, _, err = s.UseCase.ListTaskCursor(context.TODO(), member, param)
+		s.ErrorIs(err, usecase.ErrInvalidCursor)
+	})
+
+	s.Run("relevance among the keys needs a search", func() {
+		_, _, err := s.UseCase.ListTask(context.TODO(), member, model.TaskListParam{Limit: 10, Page: 1, Sort: "-priority,relevance"})
+		s.ErrorIs(err, usecase.ErrRelevanceNeedsSearch)
+	})
+
+	s.Run("priority keeps its rank in step", func() {
+		id := primitive.NewObjectID()
+		s.TaskMongoRepository.EXPECT().GetByID(mock.Anything, id.Hex(), "user-1").
+			Return(&model.Task{ID: id, OwnerID: "user-1", Status: model.TaskStatusTodo, Priority: model.TaskPriorityLow, PriorityRank: 1, Version: 1}, nil).Once()
+		s.TaskMongoRepository.EXPECT().Update(mock.Anything, id.Hex(), "user-1", int64(1), bson.M{"priority": model.TaskPriorityUrgent, "priority_rank": 4}).
+			Return(&model.Task{ID: id, OwnerID: "user-1", Status: model.TaskStatusTodo, Priority: model.TaskPriorityLow, PriorityRank: 1, Version: 2}, nil).Once()
+
+		urgent := model.TaskPriorityUrgent
+		_, err := s.UseCase.PatchTask(context.TODO(), member, id.Hex(), 0, model.TaskPatchParam{Priority: &urgent})
+		s.NoError(err)
+	})
+}
+
+func (s *TaskUseCaseTestSuite) TestListTaskSearch() {
+	tests := []struct {
+		name       string
+		params     model.TaskListParam
+		mock       func()
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:   "full-text search ranks by relevance",
+			params: model.TaskListParam{Limit: 10, Page: 1, Search: ` "release notes" -draft `},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, bson.M{"owner_id": "user-1"}, int64(1), int64(10), []model.SortKey{{Field: "relevance", Order: -1}}, `"release notes" -draft`).
+					Return(nil, 0, nil).Once()
+			},
+		},
+		{
+			name:   "full-text search keeps an explicit sort",
+			params: model.TaskListParam{Limit: 10, Page: 1, Search: "release", SortBy: "created_at", Order: -1},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, bson.M{"owner_id": "user-1"}, int64(1), int64(10), []model.SortKey{{Field: "created_at", Order: -1}}, "release").
+					Return(nil, 0, nil).Once()
+			},
+		},
+		{
+			name:   "prefix search is escaped",
+			params: model.TaskListParam{Limit: 10, Page: 1, Search: "v1.2 (beta", SearchMode: model.SearchModePrefix},
+			mock: func() {
+				s.TaskMongoRepository.EXPECT().List(mock.Anything, bson.M{
+					"owner_id": "user-1",
+					"title":    bson.M{"$regex": `^v1\.2 \(beta`, "$options": "i"},
+				}, int64(1), int64(10), []model.SortKey(nil), "").Return(nil, 0, nil).Once()
+			},
+		},
+		{
+			name:       "relevance without a search",
+			params:     model.TaskListParam{Limit: 10, Page: 1, SortBy: "relevance"},
+			mock:       func() {},
+			wantErr:    true,
+			wantErrMsg: "sorting by relevance needs a full-text search",
+		},
+		{
+			name:       "relevance with a prefix search",
+			params:     model.TaskListParam{Limit: 10, Page: 1, Search: "rel", SearchMode: model.SearchModePrefix, SortBy: "relevance"},
+			mock:       func() {},
+			wantErr:    true,
+			wantErrMsg: "sorting by relevance needs a full-text search",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tt.mock()
+			_, _, err := s.UseCase.ListTask(context.TODO(), member, tt.params)
+			if tt.wantErr {
+				s.EqualError(err, tt.wantErrMsg)
+				return
+			}
+			s.NoError(err)
+		})
+	}
+
+	s.Run("relevance can't be read by cursor", func() {
+		_, _, err := s.UseCase.ListTaskCursor(context.TODO(), member, model.TaskListParam{Limit: 10, Search: "release", SortBy: "relevance"})
+		s.ErrorIs(err, usecase.ErrRelevanceNeedsPage)
+	})
+
+	s.Run("cursor pages search without relevance", func() {
+		s.TaskMongoRepository.EXPECT().ListAfter(mock.Anything, bson.M{"owner_id": "user-1"}, int64(11), []model.SortKey(nil), "release", (*model.TaskCursor)(nil)).
+			Return(nil, nil).Once()
+
+		_, _, err := s.UseCase.ListTaskCursor(context.TODO(), member, model.TaskListParam{Limit: 10, Search: "release"})
+		s.NoError(err)
+	})
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"strings"
+)
+
+type TaskViewUseCase struct {
+	TaskViewMongoRepository interfaces.TaskViewMongoRepository
+}
+
+func NewTaskViewUseCase(taskViewMongoRepository interfaces.TaskViewMongoRepository) TaskViewUseCase {
+	return TaskViewUseCase{
+		TaskViewMongoRepository: taskViewMongoRepository,
+	}
+}
+
+// ListViews returns the views of the caller and the shared ones, an admin
+// sees every view
+func (v TaskViewUseCase) ListViews(ctx context.Context, principal model.Principal) (res []model.TaskView, err error) {
+	list, err := v.TaskViewMongoRepository.List(ctx, ownerScope(principal))
+	if err != nil {
+		return nil, err
+	}
+	res = []model.TaskView{}
+	for _, view := range list {
+		res = append(res, *view)
+	}
+	return
+}
+
+// GetView returns a view the caller may read: their own, a shared one or,
+// for an admin, any. The others are not found rather than forbidden, so ids
+// of private views don't leak.
+func (v TaskViewUseCase) GetView(ctx context.Context, principal model.Principal, id string) (res *model.TaskView, err error) {
+	view, err := v.TaskViewMongoRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if view.OwnerID != principal.UserID && !view.Shared && !principal.CanManageAll() {
+		return nil, ErrNotFound
+	}
+	return view, nil
+}
+
+func (v TaskViewUseCase) CreateView(ctx context.Context, principal model.Principal, body model.TaskViewBodyParam) (res *model.TaskView, err error) {
+	if err := checkViewFilter(body.Filter); err != nil {
+		return nil, err
+	}
+	created, err := v.TaskViewMongoRepository.Create(ctx, &model.TaskView{
+		OwnerID:  principal.UserID,
+		Name:     strings.TrimSpace(body.Name),
+		Filter:   strings.TrimSpace(body.Filter),
+		Sort:     body.Sort,
+		PageSize: body.PageSize,
+		Shared:   body.Shared,
+	})
+	if err != nil {
+		return nil, viewNameTaken(err)
+	}
+	return created, nil
+}
+
+// UpdateView replaces every field of a view the caller owns
+func (v TaskViewUseCase) UpdateView(ctx context.Context, principal model.Principal, id string, body model.TaskViewBodyParam) (res *model.TaskView, err error) {
+	if err := checkViewFilter(body.Filter); err != nil {
+		return nil, err
+	}
+	if _, err := v.owned(ctx, principal, id); err != nil {
+		return nil, err
+	}
+	updated, err := v.TaskViewMongoRepository.Update(ctx, id, bson.M{
+		"name":      strings.TrimSpace(body.Name),
+		"filter":    strings.TrimSpace(body.Filter),
+		"sort":      body.Sort,
+		"page_size": body.PageSize,
+		"shared":    body.Shared,
+	})
+	if err != nil {
+		return nil, viewNameTaken(notFound(err))
+	}
+	return updated, nil
+}
+
+func (v TaskViewUseCase) DeleteView(ctx context.Context, principal model.Principal, id string) (err error) {
+	if _, err := v.owned(ctx, principal, id); err != nil {
+		return err
+	}
+	return notFound(v.TaskViewMongoRepository.Delete(ctx, id))
+}
+
+// owned returns a view the caller may change, a shared view of someone else
+// can be read but not changed
+func (v TaskViewUseCase) owned(ctx context.Context, principal model.Principal, id string) (*model.TaskView, error) {
+	view, err := v.GetView(ctx, principal, id)
+	if err != nil {
+		return nil, err
+	}
+	if view.OwnerID != principal.UserID && !principal.CanManageAll() {
+		return nil, ErrViewNotOwner
+	}
+	return view, nil
+}
+
+// checkViewFilter parses the filter when it is saved, so a view always
+// applies cleanly
+func checkViewFilter(filter string) error {
+	if strings.TrimSpace(filter) == "" {
+		return nil
+	}
+	_, err := parseTaskFilter(filter)
+	return err
+}
+
+func viewNameTaken(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrViewNameTaken
+	}
+	return err
+}
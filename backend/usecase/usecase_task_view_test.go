@@ -0,0 +1,194 @@
+package usecase_test
+
+import (
+	"context"
+	"github.com/hendrihmwn/crud-task-backend/model"
+	"github.com/hendrihmwn/crud-task-backend/usecase"
+	"github.com/hendrihmwn/crud-task-backend/usecase/interfaces/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"testing"
+)
+
+type TaskViewUseCaseTestSuite struct {
+	suite.Suite
+
+	TaskViewMongoRepository *mocks.TaskViewMongoRepository
+	UseCase                 usecase.TaskViewUseCase
+}
+
+func TestTaskViewUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(TaskViewUseCaseTestSuite))
+}
+
+func (s *TaskViewUseCaseTestSuite) SetupTest() {
+	s.TaskViewMongoRepository = mocks.NewTaskViewMongoRepository(s.T())
+	s.UseCase = usecase.NewTaskViewUseCase(s.TaskViewMongoRepository)
+}
+
+func (s *TaskViewUseCaseTestSuite) TestListViews() {
+	s.Run("a member sees their views and the shared ones", func() {
+		s.TaskViewMongoRepository.EXPECT().List(mock.Anything, "user-1").
+			Return(nil, nil).Once()
+
+		res, err := s.UseCase.ListViews(context.TODO(), member)
+		s.NoError(err)
+		s.Equal([]model.TaskView{}, res)
+	})
+
+	s.Run("an admin sees every view", func() {
+		s.TaskViewMongoRepository.EXPECT().List(mock.Anything, "").
+			Return([]*model.TaskView{{Name: "open"}}, nil).Once()
+
+		res, err := s.UseCase.ListViews(context.TODO(), admin)
+		s.NoError(err)
+		s.Len(res, 1)
+	})
+}
+
+func (s *TaskViewUseCaseTestSuite) TestGetView() {
+	id := "68fc6a818c54acf4a737d7ab"
+	tests := []struct {
+		name      string
+		principal model.Principal
+		stored    *model.TaskView
+		storedErr error
+		wantErr   error
+	}{
+		{
+			name:      "not found",
+			principal: member,
+			storedErr: mongo.ErrNoDocuments,
+			wantErr:   usecase.ErrNotFound,
+		},
+		{
+			name:      "private view of someone else",
+			principal: member,
+			stored:    &model.TaskView{OwnerID: "user-2"},
+			wantErr:   usecase.ErrNotFound,
+		},
+		{
+			name:      "shared view of someone else",
+			principal: member,
+			stored:    &model.TaskView{OwnerID: "user-2", Shared: true},
+		},
+		{
+			name:      "own view",
+			principal: member,
+			stored:    &model.TaskView{OwnerID: "user-1"},
+		},
+		{
+			name:      "admin reads any view",
+			principal: admin,
+			stored:    &model.TaskView{OwnerID: "user-2"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.TaskViewMongoRepository.EXPECT().GetByID(mock.Anything, id).Return(tt.stored, tt.storedErr).Once()
+
+			res, err := s.UseCase.GetView(context.TODO(), tt.principal, id)
+			if tt.wantErr != nil {
+				s.ErrorIs(err, tt.wantErr)
+				return
+			}
+			s.NoError(err)
+			s.Equal(tt.stored, res)
+		})
+	}
+}
+
+func (s *TaskViewUseCaseTestSuite) TestCreateView() {
+	s.Run("error - filter doesn't parse", func() {
+		_, err := s.UseCase.CreateView(context.TODO(), member, model.TaskViewBodyParam{Name: "open", Filter: "status:done", PageSize: 10})
+		s.ErrorIs(err, usecase.ErrInvalidFilter)
+	})
+
+	s.Run("error - name taken", func() {
+		s.TaskViewMongoRepository.EXPECT().Create(mock.Anything, mock.Anything).
+			Return(nil, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}).Once()
+
+		_, err := s.UseCase.CreateView(context.TODO(), member, model.TaskViewBodyParam{Name: "open", PageSize: 10})
+		s.ErrorIs(err, usecase.ErrViewNameTaken)
+	})
+
+	s.Run("success", func() {
+		s.TaskViewMongoRepository.EXPECT().Create(mock.Anything, &model.TaskView{
+			OwnerID:  "user-1",
+			Name:     "urgent first",
+			Filter:   "status!=completed",
+			Sort:     "-priority,created_at",
+			PageSize: 20,
+			Shared:   true,
+		}).RunAndReturn(func(_ context.Context, v *model.TaskView) (*model.TaskView, error) {
+			return v, nil
+		}).Once()
+
+		res, err := s.UseCase.CreateView(context.TODO(), member, model.TaskViewBodyParam{
+			Name:     " urgent first ",
+			Filter:   " status!=completed ",
+			Sort:     "-priority,created_at",
+			PageSize: 20,
+			Shared:   true,
+		})
+		s.NoError(err)
+		s.Equal("user-1", res.OwnerID)
+	})
+}
+
+func (s *TaskViewUseCaseTestSuite) TestUpdateView() {
+	id := primitive.NewObjectID()
+	body := model.TaskViewBodyParam{Name: "open", Filter: "status:todo", PageSize: 10}
+	set := bson.M{"name": "open", "filter": "status:todo", "sort": "", "page_size": uint64(10), "shared": false}
+
+	s.Run("error - shared view of someone else", func() {
+		s.TaskViewMongoRepository.EXPECT().GetByID(mock.Anything, id.Hex()).
+			Return(&model.TaskView{ID: id, OwnerID: "user-2", Shared: true}, nil).Once()
+
+		_, err := s.UseCase.UpdateView(context.TODO(), member, id.Hex(), body)
+		s.ErrorIs(err, usecase.ErrViewNotOwner)
+	})
+
+	s.Run("success - owner", func() {
+		s.TaskViewMongoRepository.EXPECT().GetByID(mock.Anything, id.Hex()).
+			Return(&model.TaskView{ID: id, OwnerID: "user-1"}, nil).Once()
+		s.TaskViewMongoRepository.EXPECT().Update(mock.Anything, id.Hex(), set).
+			Return(&model.TaskView{ID: id, OwnerID: "user-1", Name: "open"}, nil).Once()
+
+		_, err := s.UseCase.UpdateView(context.TODO(), member, id.Hex(), body)
+		s.NoError(err)
+	})
+
+	s.Run("success - admin", func() {
+		s.TaskViewMongoRepository.EXPECT().GetByID(mock.Anything, id.Hex()).
+			Return(&model.TaskView{ID: id, OwnerID: "user-2"}, nil).Once()
+		s.TaskViewMongoRepository.EXPECT().Update(mock.Anything, id.Hex(), set).
+			Return(&model.TaskView{ID: id, OwnerID: "user-2", Name: "open"}, nil).Once()
+
+		_, err := s.UseCase.UpdateView(context.TODO(), admin, id.Hex(), body)
+		s.NoError(err)
+	})
+}
+
+func (s *TaskViewUseCaseTestSuite) TestDeleteView() {
+	id := primitive.NewObjectID()
+
+	s.Run("error - private view of someone else", func() {
+		s.TaskViewMongoRepository.EXPECT().GetByID(mock.Anything, id.Hex()).
+			Return(&model.TaskView{ID: id, OwnerID: "user-2"}, nil).Once()
+
+		err := s.UseCase.DeleteView(context.TODO(), member, id.Hex())
+		s.ErrorIs(err, usecase.ErrNotFound)
+	})
+
+	s.Run("success", func() {
+		s.TaskViewMongoRepository.EXPECT().GetByID(mock.Anything, id.Hex()).
+			Return(&model.TaskView{ID: id, OwnerID: "user-1"}, nil).Once()
+		s.TaskViewMongoRepository.EXPECT().Delete(mock.Anything, id.Hex()).Return(nil).Once()
+
+		s.NoError(s.UseCase.DeleteView(context.TODO(), member, id.Hex()))
+	})
+}
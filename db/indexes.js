@@ -26,4 +26,141 @@ db.tasks.createIndex(
   {
     name: "idx_tasks_status_createdAt_desc"
   }
-);
\ No newline at end of file
+);
+
+db.tasks.createIndex(
+  { owner_id: 1, created_at: -1 },
+  {
+    name: "idx_tasks_owner_createdAt_desc"
+  }
+);
+
+db.tasks.createIndex(
+  { owner_id: 1, due_date: 1 },
+  {
+    name: "idx_tasks_owner_dueDate"
+  }
+);
+
+db.tasks.createIndex(
+  { owner_id: 1, priority: 1, created_at: -1 },
+  {
+    name: "idx_tasks_owner_priority_createdAt_desc"
+  }
+);
+
+// sort=-priority,created_at, read backwards for its opposite
+db.tasks.createIndex(
+  { owner_id: 1, priority_rank: -1, created_at: 1 },
+  {
+    name: "idx_tasks_owner_priorityRank_createdAt"
+  }
+);
+
+// multikey, one entry per tag
+db.tasks.createIndex(
+  { owner_id: 1, tags: 1 },
+  {
+    name: "idx_tasks_owner_tags"
+  }
+);
+
+// full-text search, a title match weighs ten times a description match
+db.tasks.createIndex(
+  { title: "text", description: "text" },
+  {
+    name: "idx_tasks_text",
+    weights: { title: 10, description: 1 }
+  }
+);
+
+db.tasks.createIndex(
+  { owner_id: 1, blocked_by: 1 },
+  {
+    name: "idx_tasks_owner_blockedBy"
+  }
+);
+
+db.tasks.createIndex(
+  { deleted_at: 1 },
+  {
+    name: "idx_tasks_deleted_at"
+  }
+);
+
+db.task_events.createIndex(
+  { task_id: 1, created_at: -1 },
+  {
+    name: "idx_task_events_task_createdAt_desc"
+  }
+);
+
+db.users.createIndex(
+  { username: 1 },
+  {
+    name: "idx_users_username",
+    unique: true
+  }
+);
+
+db.refresh_tokens.createIndex(
+  { token_hash: 1 },
+  {
+    name: "idx_refresh_tokens_hash",
+    unique: true
+  }
+);
+
+db.refresh_tokens.createIndex(
+  { family_id: 1 },
+  {
+    name: "idx_refresh_tokens_family"
+  }
+);
+
+db.refresh_tokens.createIndex(
+  { expires_at: 1 },
+  {
+    name: "idx_refresh_tokens_ttl",
+    expireAfterSeconds: 0
+  }
+);
+
+db.api_tokens.createIndex(
+  { token_hash: 1 },
+  {
+    name: "idx_api_tokens_hash",
+    unique: true
+  }
+);
+
+db.api_tokens.createIndex(
+  { user_id: 1, created_at: -1 },
+  {
+    name: "idx_api_tokens_user_createdAt_desc"
+  }
+);
+
+// a user can't have two views with the same name
+db.task_views.createIndex(
+  { owner_id: 1, name: 1 },
+  {
+    name: "idx_task_views_owner_name",
+    unique: true
+  }
+);
+
+db.task_views.createIndex(
+  { shared: 1, name: 1 },
+  {
+    name: "idx_task_views_shared_name"
+  }
+);
+
+db.login_attempts.createIndex(
+  { expires_at: 1 },
+  {
+    name: "idx_login_attempts_ttl",
+    expireAfterSeconds: 0
+  }
+);
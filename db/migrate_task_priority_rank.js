@@ -0,0 +1,10 @@
+use database;
+
+// priority sorts by its rank, low 1 to urgent 4, which tasks saved before
+// multi-key sorting don't have
+["low", "medium", "high", "urgent"].forEach((priority, i) => {
+  db.tasks.updateMany(
+    { priority: priority, priority_rank: { $exists: false } },
+    { $set: { priority_rank: i + 1 } }
+  );
+});
@@ -0,0 +1,7 @@
+use database;
+
+// "in-progress" was renamed to "in_progress" when status became an enum
+db.tasks.updateMany(
+  { status: "in-progress" },
+  { $set: { status: "in_progress" } }
+);
@@ -0,0 +1,7 @@
+use database;
+
+// tasks created before versioning start at 1, so their ETag can be matched
+db.tasks.updateMany(
+  { version: { $exists: false } },
+  { $set: { version: 1 } }
+);
@@ -1,15 +1,12 @@
 import axios from "axios";
-import { useRouter } from "vue-router";
-
-const router = useRouter();
-const token = localStorage.getItem('token');
 
 const axiosClient = axios.create({
   baseURL: import.meta.env.VITE_API_BASE_URL,
-  withCredentials: (token) ? true : false,
+  withCredentials: !!localStorage.getItem('token'),
   withXSRFToken: true,
 })
 axiosClient.interceptors.request.use(config => {
+    const token = localStorage.getItem('token');
     if (token) {
         config.headers.Authorization = `Bearer ${token}`;
     }
@@ -17,15 +14,69 @@ axiosClient.interceptors.request.use(config => {
     return config;
 }, error => Promise.reject(error));
 
+// errors come as {error: {code, message, details, request_id}}
+function errorBody(error) {
+  return (error.response && error.response.data && error.response.data.error) || {};
+}
+
+function errorCode(error) {
+  return errorBody(error).code;
+}
+
+export function apiErrorMessage(error) {
+  return errorBody(error).message || 'Something went wrong, please try again';
+}
+
+function clearSession() {
+  localStorage.removeItem('token');
+  localStorage.removeItem('refresh_token');
+  window.location.href = '/login';
+}
+
+// share one refresh call between requests that fail at the same time,
+// the backend rotates refresh tokens and treats a second use as reuse
+let refreshing = null;
+
+function refreshSession() {
+  if (!refreshing) {
+    refreshing = axios.post(`${import.meta.env.VITE_API_BASE_URL}/token/refresh`, {
+      refresh_token: localStorage.getItem('refresh_token'),
+    }).then(response => {
+      localStorage.setItem('token', response.data.data.token);
+      localStorage.setItem('refresh_token', response.data.data.refresh_token);
+    }).finally(() => {
+      refreshing = null;
+    });
+  }
+  return refreshing;
+}
+
 axiosClient.interceptors.response.use((response) => {
   return response;
 }, error => {
-  if (error.response && error.response.status === 401 && error.response.data.error === 'invalid token') {
-    localStorage.removeItem('token');
-    router.push({name: 'Login'});
+  const original = error.config;
+  const code = error.response && error.response.status === 401 ? errorCode(error) : null;
+  if (code === 'token_invalid' || code === 'token_missing') {
+    clearSession();
+    throw error;
+  }
+  if (code === 'token_expired') {
+    if (!localStorage.getItem('refresh_token') || original._retried) {
+      clearSession();
+      throw error;
+    }
+    original._retried = true;
+    return refreshSession()
+      .then(() => axiosClient(original))
+      .catch(err => {
+        if (err.response && err.response.status === 401) {
+          clearSession();
+        }
+        throw err;
+      });
   }
 
   throw error;
 })
 
-export default axiosClient
\ No newline at end of file
+export default axiosClient